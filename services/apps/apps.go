@@ -5,14 +5,43 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/tracing"
 )
 
+const (
+	// appTaskPollInterval is the time between two task status requests.
+	appTaskPollInterval = 5 * time.Second
+	// appTaskTimeout is the maximum time to wait for an application to be
+	// started, stopped or installed.
+	appTaskTimeout = 100 * time.Second
+	// migrateTimeout is the maximum time to wait for a migration, which
+	// copies the whole application to another volume.
+	migrateTimeout = time.Hour
+)
+
+// DefaultCacheTTL is a CacheTTL suitable for dashboards polling many NAS
+// units. It is short enough to see state changes made outside of the client
+// within a few seconds.
+const DefaultCacheTTL = 5 * time.Second
+
 type Client struct {
 	BaseClient
+	// CodeSigningPolicy is checked before applications are installed or started.
+	CodeSigningPolicy CodeSigningPolicy
+	// Language is used for localized strings. If empty DefaultLanguage is used.
+	// It can be overridden per call with WithLanguage.
+	Language Language
+	// CacheTTL enables sharing the result of List and ListStates for the given
+	// duration. Operations changing the state of an application invalidate the
+	// cached result. Caching is disabled if CacheTTL is zero, the default;
+	// DefaultCacheTTL suits dashboards.
+	CacheTTL time.Duration
+
+	cache *listCache
 }
 
 // NewClient creates an instance of the Client client.
@@ -22,7 +51,7 @@ func NewClient() Client {
 
 // NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
 func NewClientWithBaseURI(baseURI string) Client {
-	return Client{NewWithBaseURI(baseURI)}
+	return Client{BaseClient: NewWithBaseURI(baseURI), cache: newListCache()}
 }
 
 func (client Client) List(ctx context.Context) (result ListResponse, err error) {
@@ -36,6 +65,14 @@ func (client Client) List(ctx context.Context) (result ListResponse, err error)
 			tracing.EndSpan(ctx, sc, err)
 		}()
 	}
+	result, err = client.cache.load(ctx, client.language(ctx), client.CacheTTL, func() (ListResponse, error) {
+		return client.list(ctx)
+	})
+	return
+}
+
+// list sends the reload request of List, bypassing the cache.
+func (client Client) list(ctx context.Context) (result ListResponse, err error) {
 	req, err := client.ListPreparer(ctx)
 	if err != nil {
 		err = autorest.NewErrorWithError(err, "apps.Client", "List", nil, "Failure preparing request")
@@ -66,7 +103,7 @@ func (client Client) ListPreparer(ctx context.Context) (*http.Request, error) {
 			"subfunc": []string{"qpkg"},
 			"apply":   []string{"10"},
 			"action":  []string{"reload"},
-			"lang":    []string{"eng"},
+			"lang":    []string{string(client.language(ctx))},
 		}))
 	return preparer.Prepare((&http.Request{}).WithContext(ctx))
 }
@@ -106,6 +143,7 @@ func (client Client) ListResponder(resp *http.Response) (result ListResponse, er
 				Status:             item.Attr.Status,
 				BootRunStatus:      item.Attr.BootRunStatus,
 				ShutdownStopStatus: item.Attr.ShutdownStopStatus,
+				BootBehavior:       parseBootBehavior(item.Attr.BootRunStatus, item.Attr.ShutdownStopStatus),
 				Enabled:            item.Attr.Enable == "TRUE",
 				Installed:          item.Attr.Installed == "1",
 			},
@@ -115,7 +153,7 @@ func (client Client) ListResponder(resp *http.Response) (result ListResponse, er
 			ShellPath:         filterNullString(item.Attr.ShellPath),
 			Shell:             filterNullString(item.Attr.Shell),
 			ServPort:          filterNullString(item.Attr.ServPort),
-			Unofficial:        filterNullString(item.Attr.Unofficial),
+			Unofficial:        parseOfficialStatus(item.Attr.Unofficial),
 			IncompleteConf:    filterNullString(item.Attr.IncompleteConf),
 			WebPort:           item.Attr.WebPort,
 			WebSSLPort:        item.Attr.WebSSLPort,
@@ -138,7 +176,7 @@ func (client Client) ListResponder(resp *http.Response) (result ListResponse, er
 			AppRouteRule:      filterNullString(item.Attr.AppRouteRule),
 			FwVerMax:          filterNullString(item.Attr.FwVerMax),
 			FwVerMin:          filterNullString(item.Attr.FwVerMin),
-			CodeSigningStatus: filterNullString(item.Attr.CodeSigningStatus),
+			CodeSigningStatus: parseCodeSigningStatus(item.Attr.CodeSigningStatus),
 			DepCnt:            filterNullString(item.Attr.DepCnt),
 			DepList:           filterNullString(item.Attr.DepList),
 		}
@@ -158,6 +196,21 @@ func (client Client) ListStates(ctx context.Context) (result StatesResponse, err
 			tracing.EndSpan(ctx, sc, err)
 		}()
 	}
+	if client.CacheTTL > 0 && client.cache != nil {
+		var list ListResponse
+		list, err = client.List(ctx)
+		result.Response = list.Response
+		if err != nil {
+			return
+		}
+
+		result.AppStates = make([]ApplicationState, len(list.Apps))
+		for i, app := range list.Apps {
+			result.AppStates[i] = app.ApplicationState
+		}
+		return
+	}
+
 	req, err := client.ListStatesPreparer(ctx)
 	if err != nil {
 		err = autorest.NewErrorWithError(err, "apps.Client", "ListStates", nil, "Failure preparing request")
@@ -188,7 +241,7 @@ func (client Client) ListStatesPreparer(ctx context.Context) (*http.Request, err
 			"subfunc": []string{"qpkg"},
 			"apply":   []string{"10"},
 			"action":  []string{"reload"},
-			"lang":    []string{"eng"},
+			"lang":    []string{string(client.language(ctx))},
 		}))
 	return preparer.Prepare((&http.Request{}).WithContext(ctx))
 }
@@ -198,7 +251,7 @@ func (client Client) ListStatesSender(req *http.Request) (*http.Response, error)
 }
 
 func (client Client) ListStatesResponder(resp *http.Response) (result StatesResponse, err error) {
-	var doc qdocAppList
+	var doc qdocAppStateList
 	err = autorest.Respond(
 		resp,
 		autorest.WithErrorUnlessStatusCode(http.StatusOK),
@@ -228,6 +281,7 @@ func (client Client) ListStatesResponder(resp *http.Response) (result StatesResp
 			Status:             item.Attr.Status,
 			BootRunStatus:      item.Attr.BootRunStatus,
 			ShutdownStopStatus: item.Attr.ShutdownStopStatus,
+			BootBehavior:       parseBootBehavior(item.Attr.BootRunStatus, item.Attr.ShutdownStopStatus),
 			Enabled:            item.Attr.Enable == "TRUE",
 			Installed:          item.Attr.Installed == "1",
 		}
@@ -244,6 +298,12 @@ func (client Client) Start(ctx context.Context, qname string, dontWait bool) (er
 			tracing.EndSpan(ctx, sc, err)
 		}()
 	}
+	if client.CodeSigningPolicy.Enforce {
+		if err = client.checkCodeSigningPolicy(ctx, qname, false); err != nil {
+			return
+		}
+	}
+
 	req, err := client.StartPreparer(ctx, qname)
 	if err != nil {
 		err = autorest.NewErrorWithError(err, "apps.Client", "Start", nil, "Failure preparing request")
@@ -256,6 +316,8 @@ func (client Client) Start(ctx context.Context, qname string, dontWait bool) (er
 		return
 	}
 
+	client.cache.invalidate()
+
 	err = client.StartResponder(resp)
 	if err != nil {
 		err = autorest.NewErrorWithError(err, "apps.Client", "Start", resp, "Failure responding to request")
@@ -263,26 +325,7 @@ func (client Client) Start(ctx context.Context, qname string, dontWait bool) (er
 	}
 
 	if !dontWait {
-		success := false
-		for i := 0; i < 20; i++ {
-			stat, ierr := client.getAppTaskStatus(ctx)
-			if ierr != nil {
-				err = ierr
-				return
-			}
-
-			if !stat.IsRunning {
-				success = true
-				break
-			}
-
-			time.Sleep(time.Duration(5) * time.Second)
-		}
-
-		if !success {
-			err = fmt.Errorf("failed to wait for application %s getting started", qname)
-			return
-		}
+		err = client.waitForAppTask(ctx, qname, "started", appTaskTimeout, nil)
 	}
 
 	return
@@ -345,6 +388,8 @@ func (client Client) Stop(ctx context.Context, qname string, dontWait bool) (err
 		return
 	}
 
+	client.cache.invalidate()
+
 	err = client.StopResponder(resp)
 	if err != nil {
 		err = autorest.NewErrorWithError(err, "apps.Client", "Stop", resp, "Failure responding to request")
@@ -352,49 +397,265 @@ func (client Client) Stop(ctx context.Context, qname string, dontWait bool) (err
 	}
 
 	if !dontWait {
-		success := false
-		for i := 0; i < 20; i++ {
-			stat, ierr := client.getAppTaskStatus(ctx)
-			if ierr != nil {
-				err = ierr
-				return
-			}
+		err = client.waitForAppTask(ctx, qname, "stopped", appTaskTimeout, nil)
+	}
 
-			if !stat.IsRunning {
-				success = true
-				break
-			}
+	return
+}
 
-			time.Sleep(time.Duration(5) * time.Second)
-		}
+func (client Client) StopPreparer(ctx context.Context, qname string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"qpkg"},
+			"apply":   []string{"4"},
+			"block":   []string{"0"},
+			"qname":   []string{qname},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) StopSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) StopResponder(resp *http.Response) (err error) {
+	var doc qdocAppOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
 
-		if !success {
-			err = fmt.Errorf("failed to wait for application %s getting stopped", qname)
+	return
+}
+
+func (client Client) Install(ctx context.Context, qname string, dontWait bool) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Install")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	if client.CodeSigningPolicy.Enforce {
+		if err = client.checkCodeSigningPolicy(ctx, qname, true); err != nil {
 			return
 		}
 	}
 
+	req, err := client.InstallPreparer(ctx, qname)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "Install", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.InstallSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "Install", resp, "Failure sending request")
+		return
+	}
+
+	client.cache.invalidate()
+
+	err = client.InstallResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "Install", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait {
+		err = client.waitForAppTask(ctx, qname, "installed", appTaskTimeout, nil)
+	}
+
 	return
 }
 
-func (client Client) StopPreparer(ctx context.Context, qname string) (*http.Request, error) {
+func (client Client) InstallPreparer(ctx context.Context, qname string) (*http.Request, error) {
 	preparer := autorest.CreatePreparer(
 		autorest.AsPost(),
 		autorest.WithBaseURL(client.BaseURI),
 		autorest.WithFormData(url.Values{
 			"subfunc": []string{"qpkg"},
-			"apply":   []string{"4"},
+			"apply":   []string{"2"},
 			"block":   []string{"0"},
 			"qname":   []string{qname},
 		}))
 	return preparer.Prepare((&http.Request{}).WithContext(ctx))
 }
 
-func (client Client) StopSender(req *http.Request) (*http.Response, error) {
+func (client Client) InstallSender(req *http.Request) (*http.Response, error) {
 	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
 }
 
-func (client Client) StopResponder(resp *http.Response) (err error) {
+func (client Client) InstallResponder(resp *http.Response) (err error) {
+	var doc qdocAppOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// SetEnabled enables or disables the application qname. Disabled
+// applications are stopped and not started when the NAS boots.
+func (client Client) SetEnabled(ctx context.Context, qname string, enabled bool) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetEnabled")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetEnabledPreparer(ctx, qname, enabled)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetEnabled", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetEnabledSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetEnabled", resp, "Failure sending request")
+		return
+	}
+
+	client.cache.invalidate()
+
+	err = client.SetEnabledResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetEnabled", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetEnabledPreparer(ctx context.Context, qname string, enabled bool) (*http.Request, error) {
+	enable := "FALSE"
+	if enabled {
+		enable = "TRUE"
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"qpkg"},
+			"apply":   []string{"6"},
+			"qname":   []string{qname},
+			"enable":  []string{enable},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetEnabledSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetEnabledResponder(resp *http.Response) (err error) {
+	var doc qdocAppOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// SetBootBehavior changes whether the application qname is started when the
+// NAS boots and stopped before the NAS shuts down. QTS starts and stops the
+// applications in an order of its own and exposes no setting for it, so only
+// these flags can be changed.
+func (client Client) SetBootBehavior(ctx context.Context, qname string, behavior BootBehavior) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetBootBehavior")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetBootBehaviorPreparer(ctx, qname, behavior)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetBootBehavior", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetBootBehaviorSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetBootBehavior", resp, "Failure sending request")
+		return
+	}
+
+	client.cache.invalidate()
+
+	err = client.SetBootBehaviorResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetBootBehavior", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetBootBehaviorPreparer(ctx context.Context, qname string, behavior BootBehavior) (*http.Request, error) {
+	bootRun := "0"
+	if behavior.BootRun {
+		bootRun = "1"
+	}
+	shutdownStop := "0"
+	if behavior.ShutdownStop {
+		shutdownStop = "1"
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"subfunc":              []string{"qpkg"},
+			"apply":                []string{"7"},
+			"qname":                []string{qname},
+			"boot_run_status":      []string{bootRun},
+			"shutdown_stop_status": []string{shutdownStop},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetBootBehaviorSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetBootBehaviorResponder(resp *http.Response) (err error) {
 	var doc qdocAppOp
 	err = autorest.Respond(
 		resp,
@@ -414,6 +675,374 @@ func (client Client) StopResponder(resp *http.Response) (err error) {
 	return
 }
 
+// Migrate moves the application qname to the given volume, waits until the
+// migration has finished and returns the new installation path. volume is the
+// name of the data volume, e.g. CACHEDEV2_DATA, and an error is returned if
+// the installation path is not on it afterwards.
+func (client Client) Migrate(ctx context.Context, qname, volume string) (result MigrateResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Migrate")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.MigratePreparer(ctx, qname, volume)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "Migrate", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.MigrateSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "apps.Client", "Migrate", resp, "Failure sending request")
+		return
+	}
+
+	client.cache.invalidate()
+
+	result, err = client.MigrateResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "Migrate", resp, "Failure responding to request")
+		return
+	}
+
+	migrated := func() (bool, error) {
+		list, err := client.list(ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, app := range list.Apps {
+			if app.ID == qname {
+				result.InstallPath = app.InstallPath
+				return onVolume(app.InstallPath, volume), nil
+			}
+		}
+		return false, fmt.Errorf("application %s not found", qname)
+	}
+	if err = client.waitForAppTask(ctx, qname, "migrated", migrateTimeout, migrated); err != nil {
+		if result.InstallPath != "" {
+			err = fmt.Errorf("%w: installation path is %s", err, result.InstallPath)
+		}
+		return
+	}
+
+	return
+}
+
+// onVolume reports whether p is on the data volume, given by its name or its
+// path below /share.
+func onVolume(p, volume string) bool {
+	volume = strings.TrimSuffix(volume, "/")
+	if !strings.HasPrefix(volume, "/") {
+		volume = "/share/" + volume
+	}
+	return strings.HasPrefix(p, volume+"/")
+}
+
+func (client Client) MigratePreparer(ctx context.Context, qname, volume string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"subfunc":       []string{"qpkg"},
+			"apply":         []string{"13"},
+			"block":         []string{"0"},
+			"qname":         []string{qname},
+			"volume_select": []string{volume},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// MigrateSender sends the request without retries, as the migration may
+// already be running when the response is lost.
+func (client Client) MigrateSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) MigrateResponder(resp *http.Response) (result MigrateResponse, err error) {
+	var doc qdocAppOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// GetCodeSigningSetting returns whether the NAS allows the installation of
+// applications without a valid digital signature.
+func (client Client) GetCodeSigningSetting(ctx context.Context) (result CodeSigningSettingResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetCodeSigningSetting")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetCodeSigningSettingPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "GetCodeSigningSetting", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetCodeSigningSettingSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "apps.Client", "GetCodeSigningSetting", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetCodeSigningSettingResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "GetCodeSigningSetting", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetCodeSigningSettingPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc":     "qpkg",
+		"apply":       "20",
+		"get_setting": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetCodeSigningSettingSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetCodeSigningSettingResponder(resp *http.Response) (result CodeSigningSettingResponse, err error) {
+	var doc qdocCodeSigningSetting
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.AllowUnsigned = doc.Func.OwnContent.AllowUnsigned == "1"
+
+	return
+}
+
+// SetCodeSigningSetting toggles the NAS-wide setting that allows the
+// installation of applications without a valid digital signature.
+func (client Client) SetCodeSigningSetting(ctx context.Context, allowUnsigned bool) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetCodeSigningSetting")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetCodeSigningSettingPreparer(ctx, allowUnsigned)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetCodeSigningSetting", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetCodeSigningSettingSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetCodeSigningSetting", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetCodeSigningSettingResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "apps.Client", "SetCodeSigningSetting", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetCodeSigningSettingPreparer(ctx context.Context, allowUnsigned bool) (*http.Request, error) {
+	allow := "0"
+	if allowUnsigned {
+		allow = "1"
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"subfunc":             []string{"qpkg"},
+			"apply":               []string{"20"},
+			"allow_unsigned_qpkg": []string{allow},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetCodeSigningSettingSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetCodeSigningSettingResponder(resp *http.Response) (err error) {
+	var doc qdocAppOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// checkCodeSigningPolicy validates the application qname against the
+// CodeSigningPolicy of the client.
+//
+// Besides the installed applications, List returns the packages offered by
+// the App Center repositories, including their signature and origin, which
+// are checked before an installation. If a repository does not report the
+// signature status, the package is only installed if it is official and
+// either the policy allows unsigned packages or the NAS itself rejects them
+// during the installation.
+func (client Client) checkCodeSigningPolicy(ctx context.Context, qname string, install bool) error {
+	list, err := client.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var app ApplicationDetails
+	found := false
+	for _, a := range list.Apps {
+		if a.ID == qname {
+			app, found = a, true
+			break
+		}
+	}
+
+	if !install {
+		if !found {
+			return fmt.Errorf("application %s not found", qname)
+		}
+		return client.CodeSigningPolicy.Check(app)
+	}
+
+	if found && (app.Installed || app.CodeSigningStatus != CodeSigningStatusUnknown) {
+		return client.CodeSigningPolicy.Check(app)
+	}
+
+	if !client.CodeSigningPolicy.AllowUnofficial && app.Unofficial != OfficialStatusOfficial {
+		return &CodeSigningPolicyError{QName: qname, Unofficial: app.Unofficial}
+	}
+	if client.CodeSigningPolicy.AllowUnsigned {
+		return nil
+	}
+
+	setting, err := client.GetCodeSigningSetting(ctx)
+	if err != nil {
+		return err
+	}
+	if setting.AllowUnsigned {
+		return &CodeSigningPolicyError{QName: qname, NASAllowsUnsigned: true}
+	}
+
+	return nil
+}
+
+func (client Client) language(ctx context.Context) Language {
+	if lang, ok := ctx.Value(languageKey{}).(Language); ok && lang != "" {
+		return lang
+	}
+	if client.Language != "" {
+		return client.Language
+	}
+	return DefaultLanguage
+}
+
+// waitForAppTask waits until no application task is running. If done is
+// set, the task may not have been registered yet when no task is running, so
+// waitForAppTask also waits until done reports the expected final state. It
+// fails if a task has been seen running and done still reports false.
+func (client Client) waitForAppTask(ctx context.Context, qname, action string, timeout time.Duration, done func() (bool, error)) error {
+	// the application state changes while the task is running
+	defer client.cache.invalidate()
+
+	seen := false
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		stat, err := client.getAppTaskStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		if stat.IsRunning {
+			seen = true
+		} else {
+			if done == nil {
+				return nil
+			}
+			ok, err := done()
+			if err != nil {
+				return err
+			}
+			if ok {
+				return nil
+			}
+			if seen {
+				return fmt.Errorf("application %s not %s after its task finished", qname, action)
+			}
+		}
+
+		t := time.NewTimer(appTaskPollInterval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("failed to wait for application %s getting %s", qname, action)
+}
+
 func (client Client) getAppTaskStatus(ctx context.Context) (result applicationTaskStatusReponse, err error) {
 	if tracing.IsEnabled() {
 		ctx = tracing.StartSpan(ctx, fqdn+"/Client.getAppTaskStatus")
@@ -12,6 +12,12 @@ type AppsClientAPI interface {
 	ListStates(ctx context.Context) (apps.StatesResponse, error)
 	Start(ctx context.Context, qname string, dontWait bool) error
 	Stop(ctx context.Context, qname string, dontWait bool) error
+	Install(ctx context.Context, qname string, dontWait bool) error
+	SetEnabled(ctx context.Context, qname string, enabled bool) error
+	SetBootBehavior(ctx context.Context, qname string, behavior apps.BootBehavior) error
+	Migrate(ctx context.Context, qname, volume string) (apps.MigrateResponse, error)
+	GetCodeSigningSetting(ctx context.Context) (apps.CodeSigningSettingResponse, error)
+	SetCodeSigningSetting(ctx context.Context, allowUnsigned bool) error
 }
 
 var _ AppsClientAPI = (*apps.Client)(nil)
@@ -0,0 +1,116 @@
+package apps
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// listCache holds the result of the last reload request so that List and
+// ListStates calls issued within Client.CacheTTL share a single request to
+// the NAS. Concurrent calls missing the cache wait for a single request as
+// well. It is shared by all copies of a Client.
+type listCache struct {
+	mu      sync.Mutex
+	lang    Language
+	fetched time.Time
+	list    ListResponse
+	// gen is incremented by invalidate, so a request started before the
+	// invalidation does not store its result.
+	gen   uint64
+	calls map[Language]*listCall
+}
+
+// listCall is a reload request other callers can wait for.
+type listCall struct {
+	done chan struct{}
+	list ListResponse
+	err  error
+	// cancelled is set if the context of the caller sending the request was
+	// done, so the error does not apply to the callers waiting for it.
+	cancelled bool
+}
+
+func newListCache() *listCache {
+	return &listCache{calls: map[Language]*listCall{}}
+}
+
+// load returns the cached list for lang if it is younger than ttl. Otherwise
+// it calls fetch, or waits for a fetch already running for lang, and caches
+// the result. fetch is called directly if caching is disabled. fetch must use
+// ctx, the context of the caller. If the context of another caller whose
+// fetch is waited for is done, the wait is retried.
+func (c *listCache) load(ctx context.Context, lang Language, ttl time.Duration, fetch func() (ListResponse, error)) (ListResponse, error) {
+	if c == nil || ttl <= 0 {
+		return fetch()
+	}
+
+	for {
+		list, retry, err := c.loadOnce(ctx, lang, ttl, fetch)
+		if !retry {
+			return list, err
+		}
+	}
+}
+
+func (c *listCache) loadOnce(ctx context.Context, lang Language, ttl time.Duration, fetch func() (ListResponse, error)) (list ListResponse, retry bool, err error) {
+	c.mu.Lock()
+	if !c.fetched.IsZero() && c.lang == lang && time.Since(c.fetched) <= ttl {
+		list = copyList(c.list)
+		c.mu.Unlock()
+		return list, false, nil
+	}
+	if call, ok := c.calls[lang]; ok {
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			if call.cancelled && ctx.Err() == nil {
+				return ListResponse{}, true, nil
+			}
+			return copyList(call.list), false, call.err
+		case <-ctx.Done():
+			return ListResponse{}, false, ctx.Err()
+		}
+	}
+	call := &listCall{done: make(chan struct{})}
+	c.calls[lang] = call
+	gen := c.gen
+	c.mu.Unlock()
+
+	call.list, call.err = fetch()
+	call.cancelled = call.err != nil && ctx.Err() != nil
+
+	c.mu.Lock()
+	if c.calls[lang] == call {
+		delete(c.calls, lang)
+	}
+	if call.err == nil && c.gen == gen {
+		c.lang = lang
+		c.fetched = time.Now()
+		c.list = copyList(call.list)
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return copyList(call.list), false, call.err
+}
+
+func (c *listCache) invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	c.fetched = time.Time{}
+	c.list = ListResponse{}
+	// later calls must not wait for a request started before the change
+	c.calls = map[Language]*listCall{}
+}
+
+func copyList(list ListResponse) ListResponse {
+	list.Apps = append([]ApplicationDetails(nil), list.Apps...)
+	return list
+}
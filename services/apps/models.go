@@ -1,7 +1,9 @@
 package apps
 
 import (
+	"context"
 	"encoding/xml"
+	"fmt"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -16,6 +18,155 @@ func filterNullString(s string) string {
 	return s
 }
 
+// Language is a QTS language code used for localized strings like the
+// display name of an application.
+type Language string
+
+const (
+	LanguageEnglish            Language = "eng"
+	LanguageChineseSimplified  Language = "sch"
+	LanguageChineseTraditional Language = "tch"
+	LanguageCzech              Language = "cze"
+	LanguageDanish             Language = "dan"
+	LanguageGerman             Language = "ger"
+	LanguageSpanish            Language = "spa"
+	LanguageFrench             Language = "fre"
+	LanguageItalian            Language = "ita"
+	LanguageJapanese           Language = "jpn"
+	LanguageKorean             Language = "kor"
+	LanguageNorwegian          Language = "nor"
+	LanguagePolish             Language = "pol"
+	LanguageRussian            Language = "rus"
+	LanguageFinnish            Language = "fin"
+	LanguageSwedish            Language = "swe"
+	LanguageDutch              Language = "dut"
+	LanguageTurkish            Language = "tur"
+	LanguageThai               Language = "tha"
+	LanguageHungarian          Language = "hun"
+	LanguagePortuguese         Language = "por"
+	LanguageGreek              Language = "grk"
+	LanguageRomanian           Language = "rom"
+)
+
+// DefaultLanguage is used if neither the context nor the Client specify a Language.
+const DefaultLanguage = LanguageEnglish
+
+type languageKey struct{}
+
+// WithLanguage returns a copy of ctx which overrides the Language of the
+// Client for all requests made with it.
+func WithLanguage(ctx context.Context, lang Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// CodeSigningStatus describes the result of the digital signature
+// verification QTS performed for an installed QPKG.
+type CodeSigningStatus string
+
+const (
+	// CodeSigningStatusUnknown is used if QTS did not report a signature status.
+	CodeSigningStatusUnknown CodeSigningStatus = ""
+	// CodeSigningStatusUnsigned is used for packages without a digital signature.
+	CodeSigningStatusUnsigned CodeSigningStatus = "unsigned"
+	// CodeSigningStatusSigned is used for packages with a valid digital signature.
+	CodeSigningStatusSigned CodeSigningStatus = "signed"
+	// CodeSigningStatusInvalid is used for packages whose signature could not be verified.
+	CodeSigningStatusInvalid CodeSigningStatus = "invalid"
+)
+
+// parseCodeSigningStatus maps the code_signing_status attribute of a QPKG.
+// Depending on the firmware version QTS reports it as a number or as a word:
+// 1 is a verified signature, 0 no signature and a negative value or 2 a
+// signature that failed verification. Any other value, including a missing
+// attribute, is CodeSigningStatusUnknown, which CodeSigningPolicy treats like
+// an unsigned package, so an unexpected value can never allow a package.
+func parseCodeSigningStatus(s string) CodeSigningStatus {
+	switch strings.ToLower(filterNullString(s)) {
+	case "1", "signed", "true":
+		return CodeSigningStatusSigned
+	case "0", "unsigned", "false":
+		return CodeSigningStatusUnsigned
+	case "2", "-1", "invalid", "fail", "failed":
+		return CodeSigningStatusInvalid
+	default:
+		return CodeSigningStatusUnknown
+	}
+}
+
+// OfficialStatus describes whether a QPKG has been published by QNAP or by a
+// third party.
+type OfficialStatus string
+
+const (
+	// OfficialStatusUnknown is used if QTS did not report the origin of a package.
+	OfficialStatusUnknown OfficialStatus = ""
+	// OfficialStatusOfficial is used for packages published by QNAP.
+	OfficialStatusOfficial OfficialStatus = "official"
+	// OfficialStatusUnofficial is used for packages published by third parties.
+	OfficialStatusUnofficial OfficialStatus = "unofficial"
+)
+
+// parseOfficialStatus maps the unofficial attribute of a QPKG. It is a flag
+// set for packages not published by QNAP, so a true value maps to
+// OfficialStatusUnofficial and a false value to OfficialStatusOfficial. A
+// missing attribute is OfficialStatusUnknown, which CodeSigningPolicy treats
+// like an unofficial package.
+func parseOfficialStatus(s string) OfficialStatus {
+	switch strings.ToLower(filterNullString(s)) {
+	case "1", "true", "yes":
+		return OfficialStatusUnofficial
+	case "0", "false", "no":
+		return OfficialStatusOfficial
+	default:
+		return OfficialStatusUnknown
+	}
+}
+
+// CodeSigningPolicy controls which packages the Client is allowed to install
+// or start. The zero value disables all checks.
+type CodeSigningPolicy struct {
+	// Enforce enables the policy checks.
+	Enforce bool
+	// AllowUnsigned permits packages that are not signed, whose signature is
+	// invalid or whose signature status is unknown.
+	AllowUnsigned bool
+	// AllowUnofficial permits packages not published by QNAP.
+	AllowUnofficial bool
+}
+
+// Check returns a *CodeSigningPolicyError if the given application violates
+// the policy.
+func (p CodeSigningPolicy) Check(app ApplicationDetails) error {
+	if !p.Enforce {
+		return nil
+	}
+	if !p.AllowUnsigned && app.CodeSigningStatus != CodeSigningStatusSigned {
+		return &CodeSigningPolicyError{QName: app.ID, CodeSigningStatus: app.CodeSigningStatus, Unofficial: app.Unofficial}
+	}
+	if !p.AllowUnofficial && app.Unofficial != OfficialStatusOfficial {
+		return &CodeSigningPolicyError{QName: app.ID, CodeSigningStatus: app.CodeSigningStatus, Unofficial: app.Unofficial}
+	}
+	return nil
+}
+
+// CodeSigningPolicyError is returned if an operation has been refused because
+// of the CodeSigningPolicy of the Client.
+type CodeSigningPolicyError struct {
+	QName             string
+	CodeSigningStatus CodeSigningStatus
+	Unofficial        OfficialStatus
+	// NASAllowsUnsigned is set if the refusal is caused by the NAS accepting
+	// packages without a valid digital signature.
+	NASAllowsUnsigned bool
+}
+
+func (e *CodeSigningPolicyError) Error() string {
+	if e.NASAllowsUnsigned {
+		return fmt.Sprintf("application %s refused by code signing policy: NAS allows applications without a valid digital signature", e.QName)
+	}
+	return fmt.Sprintf("application %s refused by code signing policy (code signing status: %q, origin: %q)", e.QName, e.CodeSigningStatus, e.Unofficial)
+}
+
 type qdocAppList struct {
 	autorest.Response `xml:"-"`
 	XMLName           xml.Name `xml:"QDocRoot"`
@@ -76,6 +227,30 @@ type qdocAppList struct {
 	Result string `xml:"result"`
 }
 
+// qdocAppStateList is the subset of qdocAppList needed for ApplicationState.
+type qdocAppStateList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			QItem []struct {
+				Name string `xml:"name"`
+				Attr struct {
+					DisplayName        string `xml:"displayName"`
+					Date               string `xml:"date"`
+					Version            string `xml:"version"`
+					Build              string `xml:"build"`
+					Enable             string `xml:"enable"`
+					Installed          string `xml:"installed"`
+					Status             string `xml:"status"`
+					BootRunStatus      string `xml:"boot_run_status"`
+					ShutdownStopStatus string `xml:"shutdown_stop_status"`
+				} `xml:"attr"`
+			} `xml:"qItem"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
 type Application struct {
 	ID          string `xml:"id" json:"id" yaml:"id"`
 	DisplayName string `xml:"displayName" json:"displayName" yaml:"displayName"`
@@ -89,44 +264,69 @@ type ApplicationState struct {
 	Status             string `xml:"status" json:"status" yaml:"status"`
 	BootRunStatus      string `xml:"bootRunStatus" json:"bootRunStatus" yaml:"bootRunStatus"`
 	ShutdownStopStatus string `xml:"shutdownStopStatus" json:"shutdownStopStatus" yaml:"shutdownStopStatus"`
-	Enabled            bool   `xml:"enabled" json:"enabled" yaml:"enabled"`
-	Installed          bool   `xml:"installed" json:"installed" yaml:"installed"`
+	// BootBehavior is parsed from BootRunStatus and ShutdownStopStatus and
+	// can be passed to Client.SetBootBehavior.
+	BootBehavior BootBehavior `xml:"bootBehavior" json:"bootBehavior" yaml:"bootBehavior"`
+	Enabled      bool         `xml:"enabled" json:"enabled" yaml:"enabled"`
+	Installed    bool         `xml:"installed" json:"installed" yaml:"installed"`
+}
+
+// parseBootBehavior maps the boot_run_status and shutdown_stop_status
+// attributes, which are flags set to 1 like in SetBootBehavior.
+func parseBootBehavior(bootRun, shutdownStop string) BootBehavior {
+	flag := func(s string) bool {
+		switch strings.ToLower(strings.TrimSpace(filterNullString(s))) {
+		case "1", "true", "yes":
+			return true
+		}
+		return false
+	}
+	return BootBehavior{BootRun: flag(bootRun), ShutdownStop: flag(shutdownStop)}
+}
+
+// BootBehavior controls how an application is handled when the NAS boots
+// or shuts down.
+type BootBehavior struct {
+	// BootRun starts the application when the NAS boots.
+	BootRun bool `xml:"bootRun" json:"bootRun" yaml:"bootRun"`
+	// ShutdownStop stops the application before the NAS shuts down.
+	ShutdownStop bool `xml:"shutdownStop" json:"shutdownStop" yaml:"shutdownStop"`
 }
 
 type ApplicationDetails struct {
 	ApplicationState  `yaml:",inline"`
-	QPKGFile          string `xml:"qpkgFile" json:"qpkgFile" yaml:"qpkgFile"`
-	InstallPath       string `xml:"installPath" json:"installPath" yaml:"installPath"`
-	ConfigPath        string `xml:"configPath,omitempty" json:"configPath,omitempty" yaml:"configPath,omitempty"`
-	ShellPath         string `xml:"shellPath,omitempty" json:"shellPath,omitempty" yaml:"shellPath,omitempty"`
-	Shell             string `xml:"shell,omitempty" json:"shell,omitempty" yaml:"shell,omitempty"`
-	ServPort          string `xml:"-" json:"-" yaml:"-"`
-	Unofficial        string `xml:"-" json:"-" yaml:"-"`
-	IncompleteConf    string `xml:"-" json:"-" yaml:"-"`
-	WebPort           int    `xml:"webPort" json:"webPort" yaml:"webPort"`
-	WebSSLPort        int    `xml:"webSSLPort" json:"webSSLPort" yaml:"webSSLPort"`
-	WebUI             string `xml:"-" json:"-" yaml:"-"`
-	Provider          string `xml:"provider,omitempty" json:"provider,omitempty" yaml:"provider,omitempty"`
-	Author            string `xml:"author" json:"author" yaml:"author"`
-	Visible           string `xml:"-" json:"-" yaml:"-"`
-	ForceVisible      string `xml:"-" json:"-" yaml:"-"`
-	TaskInfo          string `xml:"-" json:"-" yaml:"-"`
-	SysApp            bool   `xml:"sysApp" json:"sysApp" yaml:"sysApp"`
-	Desktop           string `xml:"-" json:"-" yaml:"-"`
-	Class             string `xml:"-" json:"-" yaml:"-"`
-	Store             string `xml:"store,omitempty" json:"store,omitempty" yaml:"store,omitempty"`
-	UserDataPath      string `xml:"userDataPath,omitempty" json:"userDataPath,omitempty" yaml:"userDataPath,omitempty"`
-	OpenIn            string `xml:"-" json:"-" yaml:"-"`
-	AddOn             string `xml:"-" json:"-" yaml:"-"`
-	LoginScreen       string `xml:"-" json:"-" yaml:"-"`
-	VolumeSelect      string `xml:"-" json:"-" yaml:"-"`
-	AppRoute          string `xml:"-" json:"-" yaml:"-"`
-	AppRouteRule      string `xml:"-" json:"-" yaml:"-"`
-	FwVerMax          string `xml:"fwVerMax,omitempty" json:"fwVerMax,omitempty" yaml:"fwVerMax,omitempty"`
-	FwVerMin          string `xml:"fwVerMin,omitempty" json:"fwVerMin,omitempty" yaml:"fwVerMin,omitempty"`
-	CodeSigningStatus string `xml:"-" json:"-" yaml:"-"`
-	DepCnt            string `xml:"-" json:"-" yaml:"-"`
-	DepList           string `xml:"-" json:"-" yaml:"-"`
+	QPKGFile          string            `xml:"qpkgFile" json:"qpkgFile" yaml:"qpkgFile"`
+	InstallPath       string            `xml:"installPath" json:"installPath" yaml:"installPath"`
+	ConfigPath        string            `xml:"configPath,omitempty" json:"configPath,omitempty" yaml:"configPath,omitempty"`
+	ShellPath         string            `xml:"shellPath,omitempty" json:"shellPath,omitempty" yaml:"shellPath,omitempty"`
+	Shell             string            `xml:"shell,omitempty" json:"shell,omitempty" yaml:"shell,omitempty"`
+	ServPort          string            `xml:"-" json:"-" yaml:"-"`
+	Unofficial        OfficialStatus    `xml:"unofficial,omitempty" json:"unofficial,omitempty" yaml:"unofficial,omitempty"`
+	IncompleteConf    string            `xml:"-" json:"-" yaml:"-"`
+	WebPort           int               `xml:"webPort" json:"webPort" yaml:"webPort"`
+	WebSSLPort        int               `xml:"webSSLPort" json:"webSSLPort" yaml:"webSSLPort"`
+	WebUI             string            `xml:"-" json:"-" yaml:"-"`
+	Provider          string            `xml:"provider,omitempty" json:"provider,omitempty" yaml:"provider,omitempty"`
+	Author            string            `xml:"author" json:"author" yaml:"author"`
+	Visible           string            `xml:"-" json:"-" yaml:"-"`
+	ForceVisible      string            `xml:"-" json:"-" yaml:"-"`
+	TaskInfo          string            `xml:"-" json:"-" yaml:"-"`
+	SysApp            bool              `xml:"sysApp" json:"sysApp" yaml:"sysApp"`
+	Desktop           string            `xml:"-" json:"-" yaml:"-"`
+	Class             string            `xml:"-" json:"-" yaml:"-"`
+	Store             string            `xml:"store,omitempty" json:"store,omitempty" yaml:"store,omitempty"`
+	UserDataPath      string            `xml:"userDataPath,omitempty" json:"userDataPath,omitempty" yaml:"userDataPath,omitempty"`
+	OpenIn            string            `xml:"-" json:"-" yaml:"-"`
+	AddOn             string            `xml:"-" json:"-" yaml:"-"`
+	LoginScreen       string            `xml:"-" json:"-" yaml:"-"`
+	VolumeSelect      string            `xml:"-" json:"-" yaml:"-"`
+	AppRoute          string            `xml:"-" json:"-" yaml:"-"`
+	AppRouteRule      string            `xml:"-" json:"-" yaml:"-"`
+	FwVerMax          string            `xml:"fwVerMax,omitempty" json:"fwVerMax,omitempty" yaml:"fwVerMax,omitempty"`
+	FwVerMin          string            `xml:"fwVerMin,omitempty" json:"fwVerMin,omitempty" yaml:"fwVerMin,omitempty"`
+	CodeSigningStatus CodeSigningStatus `xml:"codeSigningStatus,omitempty" json:"codeSigningStatus,omitempty" yaml:"codeSigningStatus,omitempty"`
+	DepCnt            string            `xml:"-" json:"-" yaml:"-"`
+	DepList           string            `xml:"-" json:"-" yaml:"-"`
 }
 
 type ApplicationUpdateInfo struct {
@@ -150,6 +350,12 @@ type UpdateListResponse struct {
 	Apps              []ApplicationUpdateInfo
 }
 
+// MigrateResponse contains the installation path of a migrated application.
+type MigrateResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	InstallPath       string `xml:"installPath" json:"installPath" yaml:"installPath"`
+}
+
 type qdocAppOp struct {
 	XMLName    xml.Name `xml:"QDocRoot" json:"qdocroot,omitempty"`
 	AuthPassed int      `xml:"authPassed"`
@@ -195,3 +401,23 @@ type applicationTaskStatusReponse struct {
 	Operation       string
 	IsUpdate        bool
 }
+
+type qdocCodeSigningSetting struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		Name       string `xml:"name"`
+		OwnContent struct {
+			AllowUnsigned string `xml:"allow_unsigned_qpkg"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+// CodeSigningSettingResponse contains the NAS-wide code signing setting of the
+// App Center.
+type CodeSigningSettingResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// AllowUnsigned is set if the NAS allows the installation of applications
+	// without a valid digital signature.
+	AllowUnsigned bool `xml:"allowUnsigned" json:"allowUnsigned" yaml:"allowUnsigned"`
+}
@@ -171,3 +171,77 @@ func (client Client) LogoutResponder(resp *http.Response) (err error) {
 
 	return
 }
+
+// GetBootStatus queries the unauthenticated login page for the boot and
+// shutdown state of the NAS.
+func (client Client) GetBootStatus(ctx context.Context) (result BootStatusResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetBootStatus")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+
+	req, err := client.GetBootStatusPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "core.Client", "GetBootStatus", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetBootStatusSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "core.Client", "GetBootStatus", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetBootStatusResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "core.Client", "GetBootStatus", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetBootStatusPreparer(ctx context.Context) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/authLogin.cgi"))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetBootStatusSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetBootStatusResponder(resp *http.Response) (result BootStatusResponse, err error) {
+	var doc qdocRoot
+
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	result.IsBooting = doc.IsBooting == "1"
+	result.MediaReady = doc.MediaReady == "1"
+	result.ShutdownInfo = ShutdownInfo{
+		Type:      doc.ShutdownInfo.Type,
+		Timestamp: doc.ShutdownInfo.Timestamp,
+		Duration:  doc.ShutdownInfo.Duration,
+	}
+	result.FirmwareVersion = doc.Firmware.Version
+	result.FirmwareBuild = doc.Firmware.Build
+
+	return
+}
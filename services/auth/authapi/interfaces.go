@@ -10,6 +10,7 @@ import (
 type AuthClientAPI interface {
 	Login(ctx context.Context, username, password string) (auth.LoginResponse, error)
 	Logout(ctx context.Context, sid string) error
+	GetBootStatus(ctx context.Context) (auth.BootStatusResponse, error)
 }
 
 var _ AuthClientAPI = (*auth.Client)(nil)
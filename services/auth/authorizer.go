@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+// SessionAuthorizer authorizes requests with the session ID returned by
+// Client.Login by adding it as the sid query parameter.
+type SessionAuthorizer struct {
+	Sid string
+}
+
+// NewSessionAuthorizer creates a SessionAuthorizer for the session sid.
+func NewSessionAuthorizer(sid string) *SessionAuthorizer {
+	return &SessionAuthorizer{Sid: sid}
+}
+
+// WithAuthorization returns a PrepareDecorator that adds the sid query
+// parameter to the request.
+func (a *SessionAuthorizer) WithAuthorization() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			return autorest.Prepare(r, autorest.WithQueryParameters(map[string]interface{}{
+				"sid": autorest.Encode("query", a.Sid),
+			}))
+		})
+	}
+}
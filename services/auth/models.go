@@ -21,6 +21,12 @@ type qdocRoot struct {
 		Timestamp string `xml:"timestamp"`
 		Duration  string `xml:"duration"`
 	} `xml:"shutdown_info"`
+	Firmware struct {
+		Version   string `xml:"version"`
+		Build     string `xml:"build"`
+		Number    string `xml:"number"`
+		BuildTime string `xml:"buildTime"`
+	} `xml:"firmware"`
 	SMBFW           string `xml:"SMBFW"`
 	HeroModel       string `xml:"hero_model"`
 	QtsModeType     string `xml:"qts_mode_type"`
@@ -53,3 +59,19 @@ type LoginResponse struct {
 	Username   string
 	Groupname  string
 }
+
+// ShutdownInfo describes a pending or the last shutdown of the NAS.
+type ShutdownInfo struct {
+	Type      string
+	Timestamp string
+	Duration  string
+}
+
+type BootStatusResponse struct {
+	autorest.Response
+	IsBooting       bool
+	MediaReady      bool
+	ShutdownInfo    ShutdownInfo
+	FirmwareVersion string
+	FirmwareBuild   string
+}
@@ -0,0 +1,987 @@
+package fileservices
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// GetSMB returns the settings of the SMB service.
+func (client Client) GetSMB(ctx context.Context) (result SMBResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSMB")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSMBPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetSMB", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetSMBSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetSMB", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetSMBResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetSMB", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSMBPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "smb_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetSMBSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetSMBResponder(resp *http.Response) (result SMBResponse, err error) {
+	var doc qdocSMB
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	c := doc.Func.OwnContent
+	result.Enabled = c.Enable == "1"
+	result.Workgroup = filterNullString(c.Workgroup)
+	result.MinProtocol = SMBProtocol(filterNullString(c.MinProtocol))
+	result.MaxProtocol = SMBProtocol(filterNullString(c.MaxProtocol))
+	result.RequireSigning = c.RequireSigning == "1"
+
+	return
+}
+
+// SetSMB replaces the settings of the SMB service.
+func (client Client) SetSMB(ctx context.Context, settings SMBSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetSMB")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	if settings.MinProtocol != "" && settings.MaxProtocol != "" {
+		var older bool
+		if older, err = settings.MaxProtocol.Less(settings.MinProtocol); err != nil {
+			return
+		}
+		if older {
+			err = fmt.Errorf("maximum SMB protocol %s is older than minimum %s", settings.MaxProtocol, settings.MinProtocol)
+			return
+		}
+	}
+
+	req, err := client.SetSMBPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetSMB", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetSMBSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetSMB", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetSMBResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetSMB", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetSMBPreparer(ctx context.Context, settings SMBSettings) (*http.Request, error) {
+	data := url.Values{
+		"subfunc":        []string{"smb_setting"},
+		"func":           []string{"set_setting"},
+		"enable":         []string{boolString(settings.Enabled)},
+		"server_signing": []string{boolString(settings.RequireSigning)},
+	}
+	if settings.Workgroup != "" {
+		data.Set("workgroup", settings.Workgroup)
+	}
+	if settings.MinProtocol != "" {
+		data.Set("min_protocol", string(settings.MinProtocol))
+	}
+	if settings.MaxProtocol != "" {
+		data.Set("max_protocol", string(settings.MaxProtocol))
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetSMBSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetSMBResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetNFS returns the settings of the NFS service.
+func (client Client) GetNFS(ctx context.Context) (result NFSResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetNFS")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetNFSPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetNFS", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetNFSSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetNFS", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetNFSResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetNFS", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetNFSPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "nfs_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetNFSSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetNFSResponder(resp *http.Response) (result NFSResponse, err error) {
+	var doc qdocNFS
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Enabled = doc.Func.OwnContent.Enable == "1"
+	result.V3 = doc.Func.OwnContent.V3 == "1"
+	result.V4 = doc.Func.OwnContent.V4 == "1"
+
+	return
+}
+
+// SetNFS replaces the settings of the NFS service.
+func (client Client) SetNFS(ctx context.Context, settings NFSSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetNFS")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetNFSPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetNFS", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetNFSSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetNFS", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetNFSResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetNFS", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetNFSPreparer(ctx context.Context, settings NFSSettings) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"nfs_setting"},
+			"func":    []string{"set_setting"},
+			"enable":  []string{boolString(settings.Enabled)},
+			"nfs_v3":  []string{boolString(settings.V3)},
+			"nfs_v4":  []string{boolString(settings.V4)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetNFSSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetNFSResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetNFSExport returns the host access rules of the NFS export of the
+// shared folder share. No rules means the share is not exported.
+func (client Client) GetNFSExport(ctx context.Context, share string) (result NFSExportResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetNFSExport")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetNFSExportPreparer(ctx, share)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetNFSExport", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetNFSExportSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetNFSExport", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetNFSExportResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetNFSExport", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetNFSExportPreparer(ctx context.Context, share string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc":   "nfs_setting",
+		"func":      "get_export",
+		"sharename": autorest.Encode("query", share),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetNFSExportSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetNFSExportResponder(resp *http.Response) (result NFSExportResponse, err error) {
+	var doc qdocNFSExport
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Share = doc.Func.OwnContent.Share
+	result.Rules = make([]NFSRule, len(doc.Func.OwnContent.Rule))
+	for i, rule := range doc.Func.OwnContent.Rule {
+		result.Rules[i] = NFSRule{
+			Host:   rule.Host,
+			Access: NFSAccess(rule.Access),
+			Squash: NFSSquash(rule.Squash),
+		}
+	}
+
+	return
+}
+
+// SetNFSExport replaces the host access rules of the NFS export of the shared
+// folder share. An empty list of rules stops exporting the share.
+func (client Client) SetNFSExport(ctx context.Context, share string, rules []NFSRule) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetNFSExport")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	for _, rule := range rules {
+		if rule.Access == "" {
+			err = fmt.Errorf("NFS rule for host %q has no access", rule.Host)
+			return
+		}
+	}
+
+	req, err := client.SetNFSExportPreparer(ctx, share, rules)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetNFSExport", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetNFSExportSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetNFSExport", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetNFSExportResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetNFSExport", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetNFSExportPreparer(ctx context.Context, share string, rules []NFSRule) (*http.Request, error) {
+	data := url.Values{
+		"subfunc":    []string{"nfs_setting"},
+		"func":       []string{"set_export"},
+		"sharename":  []string{share},
+		"rule_count": []string{strconv.Itoa(len(rules))},
+	}
+	for i, rule := range rules {
+		n := strconv.Itoa(i)
+		squash := rule.Squash
+		if squash == "" {
+			squash = NFSRootSquash
+		}
+		data.Set("host"+n, rule.Host)
+		data.Set("access"+n, string(rule.Access))
+		data.Set("squash"+n, string(squash))
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetNFSExportSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetNFSExportResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetAFP returns the settings of the AFP service.
+func (client Client) GetAFP(ctx context.Context) (result AFPResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetAFP")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetAFPPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetAFP", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetAFPSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetAFP", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetAFPResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetAFP", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetAFPPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "afp_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetAFPSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetAFPResponder(resp *http.Response) (result AFPResponse, err error) {
+	var doc qdocService
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Enabled = doc.Func.OwnContent.Enable == "1"
+
+	return
+}
+
+// SetAFP replaces the settings of the AFP service.
+func (client Client) SetAFP(ctx context.Context, settings AFPSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetAFP")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetAFPPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetAFP", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetAFPSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetAFP", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetAFPResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetAFP", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetAFPPreparer(ctx context.Context, settings AFPSettings) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"afp_setting"},
+			"func":    []string{"set_setting"},
+			"enable":  []string{boolString(settings.Enabled)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetAFPSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetAFPResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetFTP returns the settings of the FTP service.
+func (client Client) GetFTP(ctx context.Context) (result FTPResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetFTP")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetFTPPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetFTP", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetFTPSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetFTP", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetFTPResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetFTP", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetFTPPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "ftp_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetFTPSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetFTPResponder(resp *http.Response) (result FTPResponse, err error) {
+	var doc qdocService
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	c := doc.Func.OwnContent
+	result.Enabled = c.Enable == "1"
+	result.Port = parseInt(c.Port)
+	result.RequireTLS = c.TLS == "1"
+	result.Anonymous = c.Anonymous == "1"
+
+	return
+}
+
+// SetFTP replaces the settings of the FTP service.
+func (client Client) SetFTP(ctx context.Context, settings FTPSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetFTP")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetFTPPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetFTP", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetFTPSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetFTP", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetFTPResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetFTP", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetFTPPreparer(ctx context.Context, settings FTPSettings) (*http.Request, error) {
+	port := settings.Port
+	if port == 0 {
+		port = 21
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":   []string{"ftp_setting"},
+			"func":      []string{"set_setting"},
+			"enable":    []string{boolString(settings.Enabled)},
+			"port":      []string{strconv.Itoa(port)},
+			"tls":       []string{boolString(settings.RequireTLS)},
+			"anonymous": []string{boolString(settings.Anonymous)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetFTPSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetFTPResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetWebDAV returns the settings of the WebDAV service.
+func (client Client) GetWebDAV(ctx context.Context) (result WebDAVResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetWebDAV")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetWebDAVPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetWebDAV", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetWebDAVSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetWebDAV", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetWebDAVResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "GetWebDAV", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetWebDAVPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "webdav_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetWebDAVSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetWebDAVResponder(resp *http.Response) (result WebDAVResponse, err error) {
+	var doc qdocService
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	c := doc.Func.OwnContent
+	result.Enabled = c.Enable == "1"
+	result.Port = parseInt(c.Port)
+	result.HTTPSEnabled = c.SSLEnable == "1"
+	result.HTTPSPort = parseInt(c.SSLPort)
+
+	return
+}
+
+// SetWebDAV replaces the settings of the WebDAV service.
+func (client Client) SetWebDAV(ctx context.Context, settings WebDAVSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetWebDAV")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetWebDAVPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetWebDAV", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetWebDAVSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetWebDAV", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetWebDAVResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "fileservices.Client", "SetWebDAV", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetWebDAVPreparer(ctx context.Context, settings WebDAVSettings) (*http.Request, error) {
+	port := settings.Port
+	if port == 0 {
+		port = 8080
+	}
+	httpsPort := settings.HTTPSPort
+	if httpsPort == 0 {
+		httpsPort = 8081
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":    []string{"webdav_setting"},
+			"func":       []string{"set_setting"},
+			"enable":     []string{boolString(settings.Enabled)},
+			"port":       []string{strconv.Itoa(port)},
+			"ssl_enable": []string{boolString(settings.HTTPSEnabled)},
+			"ssl_port":   []string{strconv.Itoa(httpsPort)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetWebDAVSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetWebDAVResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
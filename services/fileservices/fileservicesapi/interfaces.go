@@ -0,0 +1,25 @@
+package fileservicesapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/fileservices"
+)
+
+// FileServicesClientAPI contains the set of methods on the fileservices.Client type.
+type FileServicesClientAPI interface {
+	GetSMB(ctx context.Context) (fileservices.SMBResponse, error)
+	SetSMB(ctx context.Context, settings fileservices.SMBSettings) error
+	GetNFS(ctx context.Context) (fileservices.NFSResponse, error)
+	SetNFS(ctx context.Context, settings fileservices.NFSSettings) error
+	GetNFSExport(ctx context.Context, share string) (fileservices.NFSExportResponse, error)
+	SetNFSExport(ctx context.Context, share string, rules []fileservices.NFSRule) error
+	GetAFP(ctx context.Context) (fileservices.AFPResponse, error)
+	SetAFP(ctx context.Context, settings fileservices.AFPSettings) error
+	GetFTP(ctx context.Context) (fileservices.FTPResponse, error)
+	SetFTP(ctx context.Context, settings fileservices.FTPSettings) error
+	GetWebDAV(ctx context.Context) (fileservices.WebDAVResponse, error)
+	SetWebDAV(ctx context.Context, settings fileservices.WebDAVSettings) error
+}
+
+var _ FileServicesClientAPI = (*fileservices.Client)(nil)
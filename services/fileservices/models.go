@@ -0,0 +1,244 @@
+package fileservices
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/fileservices"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+func parseInt(s string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(filterNullString(s)))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+type qdocSMB struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Enable         string `xml:"enable"`
+			Workgroup      string `xml:"workgroup"`
+			MinProtocol    string `xml:"min_protocol"`
+			MaxProtocol    string `xml:"max_protocol"`
+			RequireSigning string `xml:"server_signing"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocNFS struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Enable string `xml:"enable"`
+			V3     string `xml:"nfs_v3"`
+			V4     string `xml:"nfs_v4"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocNFSExport struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Share string `xml:"sharename"`
+			Rule  []struct {
+				Host   string `xml:"host"`
+				Access string `xml:"access"`
+				Squash string `xml:"squash"`
+			} `xml:"rules>rule"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocService struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Enable    string `xml:"enable"`
+			Port      string `xml:"port"`
+			TLS       string `xml:"tls"`
+			Anonymous string `xml:"anonymous"`
+			SSLEnable string `xml:"ssl_enable"`
+			SSLPort   string `xml:"ssl_port"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocServiceOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+// SMBProtocol is a version of the SMB protocol.
+type SMBProtocol string
+
+const (
+	SMB1 SMBProtocol = "SMB1"
+	SMB2 SMBProtocol = "SMB2"
+	SMB3 SMBProtocol = "SMB3"
+)
+
+// smbDialects maps the protocol names used by QTS to the protocol family and
+// dialect. The family names SMB2 and SMB3 stand for any dialect of the
+// family and have the dialect -1.
+var smbDialects = map[SMBProtocol][2]int{
+	"NT1":     {1, 0},
+	SMB1:      {1, 0},
+	SMB2:      {2, -1},
+	"SMB2_02": {2, 2},
+	"SMB2_10": {2, 10},
+	SMB3:      {3, -1},
+	"SMB3_00": {3, 0},
+	"SMB3_02": {3, 2},
+	"SMB3_11": {3, 11},
+}
+
+// Less reports whether p is an older protocol version than o. A family name
+// such as SMB3 is neither older nor newer than a dialect of the same family
+// such as SMB3_11. An error is returned if either version is unknown,
+// including the empty version, so a check such as MinProtocol.Less(SMB2)
+// cannot pass for a value it does not understand.
+func (p SMBProtocol) Less(o SMBProtocol) (bool, error) {
+	pd, ok := smbDialects[SMBProtocol(strings.ToUpper(string(p)))]
+	if !ok {
+		return false, fmt.Errorf("unknown SMB protocol %q", p)
+	}
+	od, ok := smbDialects[SMBProtocol(strings.ToUpper(string(o)))]
+	if !ok {
+		return false, fmt.Errorf("unknown SMB protocol %q", o)
+	}
+	if pd[0] != od[0] {
+		return pd[0] < od[0], nil
+	}
+	return pd[1] >= 0 && od[1] >= 0 && pd[1] < od[1], nil
+}
+
+// SMBSettings are the settings of the Microsoft networking (SMB/CIFS)
+// service.
+type SMBSettings struct {
+	Enabled   bool   `xml:"enabled" json:"enabled" yaml:"enabled"`
+	Workgroup string `xml:"workgroup" json:"workgroup" yaml:"workgroup"`
+	// MinProtocol is the oldest protocol version accepted from clients. Set
+	// it to SMB2 or later to disable SMB1.
+	MinProtocol    SMBProtocol `xml:"minProtocol" json:"minProtocol" yaml:"minProtocol"`
+	MaxProtocol    SMBProtocol `xml:"maxProtocol" json:"maxProtocol" yaml:"maxProtocol"`
+	RequireSigning bool        `xml:"requireSigning" json:"requireSigning" yaml:"requireSigning"`
+}
+
+type SMBResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	SMBSettings       `yaml:",inline"`
+}
+
+// NFSSettings are the settings of the NFS service.
+type NFSSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	V3      bool `xml:"v3" json:"v3" yaml:"v3"`
+	V4      bool `xml:"v4" json:"v4" yaml:"v4"`
+}
+
+type NFSResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	NFSSettings       `yaml:",inline"`
+}
+
+// NFSAccess is the access granted by an NFS export rule.
+type NFSAccess string
+
+const (
+	NFSReadOnly  NFSAccess = "ro"
+	NFSReadWrite NFSAccess = "rw"
+	NFSNoAccess  NFSAccess = "deny"
+)
+
+// NFSSquash maps the users of NFS clients to the anonymous user.
+type NFSSquash string
+
+const (
+	NFSNoSquash   NFSSquash = "no_squash"
+	NFSRootSquash NFSSquash = "root_squash"
+	NFSAllSquash  NFSSquash = "all_squash"
+)
+
+// NFSRule grants hosts access to an NFS export.
+type NFSRule struct {
+	// Host is an IP address, a network in CIDR notation, a host name or "*"
+	// for all hosts.
+	Host string `xml:"host" json:"host" yaml:"host"`
+	// Access is required.
+	Access NFSAccess `xml:"access" json:"access" yaml:"access"`
+	// Squash defaults to NFSRootSquash.
+	Squash NFSSquash `xml:"squash" json:"squash" yaml:"squash"`
+}
+
+type NFSExportResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Share             string
+	Rules             []NFSRule
+}
+
+// AFPSettings are the settings of the Apple networking (AFP) service.
+type AFPSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+}
+
+type AFPResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	AFPSettings       `yaml:",inline"`
+}
+
+// FTPSettings are the settings of the FTP service.
+type FTPSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	// Port defaults to 21.
+	Port int `xml:"port" json:"port" yaml:"port"`
+	// RequireTLS only accepts connections using FTP over TLS (FTPS).
+	RequireTLS bool `xml:"requireTLS" json:"requireTLS" yaml:"requireTLS"`
+	Anonymous  bool `xml:"anonymous" json:"anonymous" yaml:"anonymous"`
+}
+
+type FTPResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	FTPSettings       `yaml:",inline"`
+}
+
+// WebDAVSettings are the settings of the WebDAV service.
+type WebDAVSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	// Port defaults to 8080.
+	Port         int  `xml:"port" json:"port" yaml:"port"`
+	HTTPSEnabled bool `xml:"httpsEnabled" json:"httpsEnabled" yaml:"httpsEnabled"`
+	// HTTPSPort defaults to 8081.
+	HTTPSPort int `xml:"httpsPort" json:"httpsPort" yaml:"httpsPort"`
+}
+
+type WebDAVResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	WebDAVSettings    `yaml:",inline"`
+}
@@ -0,0 +1,39 @@
+package filestation
+
+import (
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/qnap/core-sdk-for-go/services/auth"
+)
+
+const (
+	// DefaultBaseURI is the default URI used for the service FileStation
+	DefaultBaseURI = "/cgi-bin/filemanager/utilRequest.cgi"
+)
+
+// BaseClient is the base client for FileStation.
+type BaseClient struct {
+	autorest.Client
+	BaseURI string
+}
+
+// New creates an instance of the BaseClient client.
+func New() BaseClient {
+	return NewWithBaseURI(DefaultBaseURI)
+}
+
+// NewWithBaseURI creates an instance of the BaseClient client using a custom endpoint.
+func NewWithBaseURI(baseURI string) BaseClient {
+	return BaseClient{
+		Client:  autorest.NewClientWithUserAgent(UserAgent()),
+		BaseURI: baseURI,
+	}
+}
+
+// NewWithSession creates an instance of the BaseClient client using a custom
+// endpoint which authorizes all requests with the session sid returned by
+// auth.Client.Login.
+func NewWithSession(baseURI, sid string) BaseClient {
+	client := NewWithBaseURI(baseURI)
+	client.Authorizer = auth.NewSessionAuthorizer(sid)
+	return client
+}
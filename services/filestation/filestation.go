@@ -0,0 +1,1659 @@
+package filestation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+const (
+	// pollInterval is the time between two task status requests.
+	pollInterval = 2 * time.Second
+	// taskTimeout is the maximum time to wait for a background task if ctx
+	// has no deadline. It is generous, as copying large trees can take hours.
+	taskTimeout = 24 * time.Hour
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithSession creates an instance of the Client client using a
+// custom endpoint which reuses the session sid returned by auth.Client.Login.
+func NewClientWithSession(baseURI, sid string) Client {
+	return Client{NewWithSession(baseURI, sid)}
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// List returns a page of the entries of the directory dir.
+func (client Client) List(ctx context.Context, dir string, opts ListOptions) (result ListResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.List")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListPreparer(ctx, dir, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "List", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "List", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "List", resp, "Failure responding to request")
+		return
+	}
+
+	for i := range result.Files {
+		result.Files[i].Path = path.Join(dir, result.Files[i].Name)
+	}
+
+	return
+}
+
+func (client Client) ListPreparer(ctx context.Context, dir string, opts ListOptions) (*http.Request, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	sort := opts.Sort
+	if sort == "" {
+		sort = SortByName
+	}
+	order := "ASC"
+	if opts.Descending {
+		order = "DESC"
+	}
+	hidden := "0"
+	if opts.ShowHidden {
+		hidden = "1"
+	}
+
+	queryParameters := map[string]interface{}{
+		"func":        "get_list",
+		"is_iso":      "0",
+		"list_mode":   "all",
+		"path":        autorest.Encode("query", dir),
+		"start":       opts.Start,
+		"limit":       limit,
+		"sort":        string(sort),
+		"dir":         order,
+		"hidden_file": hidden,
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListResponder(resp *http.Response) (result ListResponse, err error) {
+	var doc jsonFileList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.Total = int(doc.Total.Int64())
+	result.Files = make([]FileInfo, len(doc.Datas))
+	for i, f := range doc.Datas {
+		result.Files[i] = newFileInfo("", f)
+	}
+
+	return
+}
+
+// Stat returns the FileInfo of the file or directory p.
+func (client Client) Stat(ctx context.Context, p string) (result StatResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Stat")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.StatPreparer(ctx, p)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Stat", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.StatSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Stat", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.StatResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Stat", resp, "Failure responding to request")
+		return
+	}
+
+	result.Path = path.Clean(p)
+
+	return
+}
+
+func (client Client) StatPreparer(ctx context.Context, p string) (*http.Request, error) {
+	dir, name := path.Split(path.Clean(p))
+
+	queryParameters := map[string]interface{}{
+		"func":      "stat",
+		"path":      autorest.Encode("query", dir),
+		"file_name": autorest.Encode("query", name),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) StatSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) StatResponder(resp *http.Response) (result StatResponse, err error) {
+	var doc jsonFileList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	if len(doc.Datas) == 0 || (doc.Datas[0].Exist != nil && *doc.Datas[0].Exist == 0) {
+		err = &StatusError{Status: StatusFileNotExist}
+		return
+	}
+	result.FileInfo = newFileInfo("", doc.Datas[0])
+
+	return
+}
+
+// ListAll returns all entries of the directory dir by requesting it page by
+// page. opts.Start is ignored.
+func (client Client) ListAll(ctx context.Context, dir string, opts ListOptions) (result ListResponse, err error) {
+	opts.Start = 0
+	for {
+		var page ListResponse
+		page, err = client.List(ctx, dir, opts)
+		result.Response = page.Response
+		if err != nil {
+			return
+		}
+
+		result.Total = page.Total
+		result.Files = append(result.Files, page.Files...)
+		if len(page.Files) == 0 || len(result.Files) >= page.Total {
+			return
+		}
+		opts.Start += len(page.Files)
+	}
+}
+
+// StartChunkedUpload starts a chunked upload into the directory destDir.
+func (client Client) StartChunkedUpload(ctx context.Context, destDir string) (result UploadSessionResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.StartChunkedUpload")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.StartChunkedUploadPreparer(ctx, destDir)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "StartChunkedUpload", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.StartChunkedUploadSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "StartChunkedUpload", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.StartChunkedUploadResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "StartChunkedUpload", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) StartChunkedUploadPreparer(ctx context.Context, destDir string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":            "start_chunked_upload",
+		"upload_root_dir": autorest.Encode("query", destDir),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) StartChunkedUploadSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) StartChunkedUploadResponder(resp *http.Response) (result UploadSessionResponse, err error) {
+	var doc jsonUploadSession
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.UploadID = doc.UploadID
+
+	return
+}
+
+// GetUploadStatus returns the number of bytes uploaded so far by the chunked
+// upload uploadID.
+func (client Client) GetUploadStatus(ctx context.Context, destDir, uploadID string) (result UploadSessionResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetUploadStatus")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetUploadStatusPreparer(ctx, destDir, uploadID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetUploadStatus", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetUploadStatusSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetUploadStatus", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetUploadStatusResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetUploadStatus", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetUploadStatusPreparer(ctx context.Context, destDir, uploadID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":            "get_chunked_upload_status",
+		"upload_root_dir": autorest.Encode("query", destDir),
+		"upload_id":       autorest.Encode("query", uploadID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetUploadStatusSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetUploadStatusResponder(resp *http.Response) (result UploadSessionResponse, err error) {
+	var doc jsonUploadSession
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.UploadID = doc.UploadID
+	result.Offset = doc.Size.Int64()
+
+	return
+}
+
+// UploadChunk streams size bytes read from chunk as part of a chunked upload.
+func (client Client) UploadChunk(ctx context.Context, opts UploadChunkOptions, chunk io.Reader, size int64) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.UploadChunk")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.UploadChunkPreparer(ctx, opts, chunk, size)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "UploadChunk", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.UploadChunkSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "UploadChunk", resp, "Failure sending request")
+		return
+	}
+
+	err = client.UploadChunkResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "UploadChunk", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) UploadChunkPreparer(ctx context.Context, opts UploadChunkOptions, chunk io.Reader, size int64) (*http.Request, error) {
+	overwrite := "0"
+	if opts.Overwrite {
+		overwrite = "1"
+	}
+
+	queryParameters := map[string]interface{}{
+		"func":            "chunked_upload",
+		"upload_id":       autorest.Encode("query", opts.UploadID),
+		"upload_root_dir": autorest.Encode("query", opts.DestDir),
+		"dest_path":       autorest.Encode("query", opts.DestDir),
+		"upload_name":     autorest.Encode("query", opts.Name),
+		"offset":          opts.Offset,
+		"filesize":        opts.FileSize,
+		"overwrite":       overwrite,
+		"multipart":       "1",
+	}
+	if !opts.ModTime.IsZero() {
+		queryParameters["settime"] = "1"
+		queryParameters["mtime"] = opts.ModTime.Unix()
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters),
+		withMultipartFile("file", opts.Name, chunk, size))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// UploadChunkSender sends the request without retries, as the chunk is
+// streamed and cannot be sent a second time.
+func (client Client) UploadChunkSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) UploadChunkResponder(resp *http.Response) (err error) {
+	var doc jsonStatus
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	return
+}
+
+// Mkdir creates the directory p. The parent directory must exist, use
+// MkdirAll to create missing parents as well.
+func (client Client) Mkdir(ctx context.Context, p string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Mkdir")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.MkdirPreparer(ctx, p)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Mkdir", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.MkdirSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Mkdir", resp, "Failure sending request")
+		return
+	}
+
+	err = client.MkdirResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Mkdir", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) MkdirPreparer(ctx context.Context, p string) (*http.Request, error) {
+	dir, name := path.Split(path.Clean(p))
+
+	queryParameters := map[string]interface{}{
+		"func":        "createdir",
+		"dest_path":   autorest.Encode("query", dir),
+		"dest_folder": autorest.Encode("query", name),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) MkdirSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) MkdirResponder(resp *http.Response) (err error) {
+	var doc jsonStatus
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	return
+}
+
+// Rename renames the file or directory p to newName within the same
+// directory.
+func (client Client) Rename(ctx context.Context, p, newName string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Rename")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.RenamePreparer(ctx, p, newName)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Rename", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.RenameSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Rename", resp, "Failure sending request")
+		return
+	}
+
+	err = client.RenameResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Rename", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) RenamePreparer(ctx context.Context, p, newName string) (*http.Request, error) {
+	dir, name := path.Split(path.Clean(p))
+
+	queryParameters := map[string]interface{}{
+		"func":        "rename",
+		"path":        autorest.Encode("query", dir),
+		"source_name": autorest.Encode("query", name),
+		"dest_name":   autorest.Encode("query", newName),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// RenameSender sends the request without retries, as a retry after a lost
+// response would fail because the source no longer exists.
+func (client Client) RenameSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) RenameResponder(resp *http.Response) (err error) {
+	var doc jsonStatus
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	return
+}
+
+// Copy copies the entries names of the directory srcDir into destDir. The
+// operation runs as a background task on the NAS; unless dontWait is set
+// Copy waits for the task to finish and reports the entries which failed
+// in the Failures of the result and as an *OperationError.
+func (client Client) Copy(ctx context.Context, srcDir string, names []string, destDir string, opts TransferOptions, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Copy")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CopyPreparer(ctx, srcDir, names, destDir, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Copy", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CopySender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Copy", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.CopyResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Copy", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		var status TaskStatusResponse
+		status, err = client.waitForTask(ctx, result.TaskID)
+		result.Failures = status.Failures
+	}
+
+	return
+}
+
+func (client Client) CopyPreparer(ctx context.Context, srcDir string, names []string, destDir string, opts TransferOptions) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"func":         []string{"copy"},
+			"source_path":  []string{srcDir},
+			"source_file":  names,
+			"source_total": []string{strconv.Itoa(len(names))},
+			"dest_path":    []string{destDir},
+			"mode":         []string{conflictMode(opts.Conflict)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CopySender sends the request without retries, as a retry after a lost
+// response would start a second copy task.
+func (client Client) CopySender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CopyResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc jsonTask
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.TaskID = string(doc.PID)
+
+	return
+}
+
+// Move moves the entries names of the directory srcDir into destDir. The
+// operation runs as a background task on the NAS; unless dontWait is set
+// Move waits for the task to finish and reports the entries which failed
+// in the Failures of the result and as an *OperationError.
+func (client Client) Move(ctx context.Context, srcDir string, names []string, destDir string, opts TransferOptions, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Move")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.MovePreparer(ctx, srcDir, names, destDir, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Move", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.MoveSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Move", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.MoveResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Move", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		var status TaskStatusResponse
+		status, err = client.waitForTask(ctx, result.TaskID)
+		result.Failures = status.Failures
+	}
+
+	return
+}
+
+func (client Client) MovePreparer(ctx context.Context, srcDir string, names []string, destDir string, opts TransferOptions) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"func":         []string{"move"},
+			"source_path":  []string{srcDir},
+			"source_file":  names,
+			"source_total": []string{strconv.Itoa(len(names))},
+			"dest_path":    []string{destDir},
+			"mode":         []string{conflictMode(opts.Conflict)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// MoveSender sends the request without retries for the same reasons as
+// CopySender and RenameSender.
+func (client Client) MoveSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) MoveResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc jsonTask
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.TaskID = string(doc.PID)
+
+	return
+}
+
+// Delete deletes the entries names of the directory dir. Deleted entries are
+// moved to the recycle bin of the share unless permanent is set. Like Copy,
+// Delete waits for the background task to finish unless dontWait is set.
+func (client Client) Delete(ctx context.Context, dir string, names []string, permanent, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Delete")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DeletePreparer(ctx, dir, names, permanent)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Delete", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Delete", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.DeleteResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Delete", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		var status TaskStatusResponse
+		status, err = client.waitForTask(ctx, result.TaskID)
+		result.Failures = status.Failures
+	}
+
+	return
+}
+
+func (client Client) DeletePreparer(ctx context.Context, dir string, names []string, permanent bool) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(url.Values{
+			"func":       []string{"delete"},
+			"path":       []string{dir},
+			"file_name":  names,
+			"file_total": []string{strconv.Itoa(len(names))},
+			"force":      []string{boolString(permanent)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// DeleteSender sends the request without retries, as a retry after a lost
+// response would report the deleted entries as missing.
+func (client Client) DeleteSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) DeleteResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc jsonTask
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.TaskID = string(doc.PID)
+
+	return
+}
+
+// GetTaskStatus returns the progress of the background task taskID.
+func (client Client) GetTaskStatus(ctx context.Context, taskID string) (result TaskStatusResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetTaskStatus")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetTaskStatusPreparer(ctx, taskID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetTaskStatus", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetTaskStatusSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetTaskStatus", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetTaskStatusResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetTaskStatus", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetTaskStatusPreparer(ctx context.Context, taskID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func": "get_task_status",
+		"pid":  autorest.Encode("query", taskID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetTaskStatusSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetTaskStatusResponder(resp *http.Response) (result TaskStatusResponse, err error) {
+	var doc jsonTaskStatus
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.TaskID = string(doc.PID)
+	if result.Status, err = parseTaskStatus(doc.State); err != nil {
+		return
+	}
+	result.Percent = int(doc.Percent.Int64())
+	for _, f := range doc.Failed {
+		result.Failures = append(result.Failures, ItemError{
+			Path: f.Path,
+			Err:  &StatusError{Status: f.Status},
+		})
+	}
+
+	return
+}
+
+// CreateShareLink creates a sharing link for the file or directory p and
+// returns its URL.
+func (client Client) CreateShareLink(ctx context.Context, p string, opts ShareLinkOptions) (result ShareLinkResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.CreateShareLink")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CreateShareLinkPreparer(ctx, p, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "CreateShareLink", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateShareLinkSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "CreateShareLink", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.CreateShareLinkResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "CreateShareLink", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CreateShareLinkPreparer(ctx context.Context, p string, opts ShareLinkOptions) (*http.Request, error) {
+	dir, name := path.Split(path.Clean(p))
+	expire := "0"
+	if !opts.Expires.IsZero() {
+		expire = strconv.FormatInt(opts.Expires.Unix(), 10)
+	}
+	data := url.Values{
+		"func":           []string{"get_share_link"},
+		"path":           []string{dir},
+		"file_name":      []string{name},
+		"file_total":     []string{"1"},
+		"expire_time":    []string{expire},
+		"access_enabled": []string{boolString(opts.AllowUpload)},
+		"network_type":   []string{"internet"},
+	}
+	if opts.Password != "" {
+		data.Set("access_code", opts.Password)
+	}
+	if opts.Hostname != "" {
+		data.Set("hostname", opts.Hostname)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CreateShareLinkSender sends the request without retries, as a retry after
+// a lost response would create a second, untracked link.
+func (client Client) CreateShareLinkSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CreateShareLinkResponder(resp *http.Response) (result ShareLinkResponse, err error) {
+	var doc jsonShareLinkList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	if len(doc.Links) == 0 {
+		err = fmt.Errorf("no share link returned")
+		return
+	}
+	result.ShareLink = newShareLink(doc.Links[0])
+
+	return
+}
+
+// ListShareLinks returns all sharing links along with their access counts.
+func (client Client) ListShareLinks(ctx context.Context) (result ShareLinkListResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListShareLinks")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListShareLinksPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "ListShareLinks", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListShareLinksSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "ListShareLinks", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListShareLinksResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "ListShareLinks", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListShareLinksPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":  "get_share_list",
+		"sort":  "datetime",
+		"dir":   "DESC",
+		"start": 0,
+		"limit": "-1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListShareLinksSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListShareLinksResponder(resp *http.Response) (result ShareLinkListResponse, err error) {
+	var doc jsonShareLinkList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.Links = make([]ShareLink, len(doc.Links))
+	for i, l := range doc.Links {
+		result.Links[i] = newShareLink(l)
+	}
+
+	return
+}
+
+// RevokeShareLink deletes the sharing link id. The URL of the link stops
+// working immediately.
+func (client Client) RevokeShareLink(ctx context.Context, id string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.RevokeShareLink")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.RevokeShareLinkPreparer(ctx, id)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "RevokeShareLink", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.RevokeShareLinkSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "RevokeShareLink", resp, "Failure sending request")
+		return
+	}
+
+	err = client.RevokeShareLinkResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "RevokeShareLink", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) RevokeShareLinkPreparer(ctx context.Context, id string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":  "delete_share",
+		"ssids": autorest.Encode("query", id),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) RevokeShareLinkSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) RevokeShareLinkResponder(resp *http.Response) (err error) {
+	var doc jsonStatus
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	return
+}
+
+// Compress creates the archive archiveName in srcDir from the entries names
+// of srcDir. Like Copy, Compress runs as a background task and waits for it
+// to finish unless dontWait is set.
+func (client Client) Compress(ctx context.Context, srcDir string, names []string, archiveName string, opts CompressOptions, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Compress")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CompressPreparer(ctx, srcDir, names, archiveName, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Compress", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CompressSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Compress", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.CompressResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Compress", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		var status TaskStatusResponse
+		status, err = client.waitForTask(ctx, result.TaskID)
+		result.Failures = status.Failures
+	}
+
+	return
+}
+
+func (client Client) CompressPreparer(ctx context.Context, srcDir string, names []string, archiveName string, opts CompressOptions) (*http.Request, error) {
+	format := opts.Format
+	if format == "" {
+		format = ArchiveZip
+	}
+	level := opts.Level
+	if level == "" {
+		level = CompressNormal
+	}
+	data := url.Values{
+		"func":          []string{"compress"},
+		"source_path":   []string{srcDir},
+		"source_file":   names,
+		"total":         []string{strconv.Itoa(len(names))},
+		"compress_name": []string{archiveName},
+		"type":          []string{string(format)},
+		"level":         []string{string(level)},
+		"mode":          []string{conflictMode(opts.Conflict)},
+	}
+	if opts.Password != "" {
+		data.Set("pwd", opts.Password)
+		data.Set("encrypt", "aes")
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CompressSender sends the request without retries, as a retry after a lost
+// response would start a second task writing the same archive.
+func (client Client) CompressSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CompressResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc jsonTask
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.TaskID = string(doc.PID)
+
+	return
+}
+
+// Extract extracts the archive into the directory destDir. Like Copy,
+// Extract runs as a background task and waits for it to finish unless
+// dontWait is set.
+func (client Client) Extract(ctx context.Context, archive, destDir string, opts ExtractOptions, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Extract")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ExtractPreparer(ctx, archive, destDir, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Extract", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ExtractSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Extract", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ExtractResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Extract", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		var status TaskStatusResponse
+		status, err = client.waitForTask(ctx, result.TaskID)
+		result.Failures = status.Failures
+	}
+
+	return
+}
+
+func (client Client) ExtractPreparer(ctx context.Context, archive, destDir string, opts ExtractOptions) (*http.Request, error) {
+	data := url.Values{
+		"func":         []string{"extract"},
+		"extract_file": []string{archive},
+		"dest_path":    []string{destDir},
+		"code_page":    []string{"UTF-8"},
+		"mode":         []string{conflictMode(opts.Conflict)},
+	}
+	if opts.Password != "" {
+		data.Set("pwd", opts.Password)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// ExtractSender sends the request without retries, as a retry after a lost
+// response would start a second task extracting into the same directory.
+func (client Client) ExtractSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) ExtractResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc jsonTask
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.TaskID = string(doc.PID)
+
+	return
+}
+
+// Search searches the directory dir and its subdirectories for entries
+// matching opts. Results are paged like List; Total of the result is the
+// number of matches regardless of paging.
+func (client Client) Search(ctx context.Context, dir string, opts SearchOptions) (result ListResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Search")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SearchPreparer(ctx, dir, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Search", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SearchSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Search", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.SearchResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Search", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SearchPreparer(ctx context.Context, dir string, opts SearchOptions) (*http.Request, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	queryParameters := map[string]interface{}{
+		"func":        "search",
+		"source_path": autorest.Encode("query", dir),
+		"keyword":     autorest.Encode("query", opts.Pattern),
+		"start":       opts.Start,
+		"limit":       limit,
+		"sort":        string(SortByName),
+		"dir":         "ASC",
+	}
+	if opts.MinSize > 0 {
+		queryParameters["size_min"] = opts.MinSize
+	}
+	if opts.MaxSize > 0 {
+		queryParameters["size_max"] = opts.MaxSize
+	}
+	if !opts.ModifiedAfter.IsZero() {
+		queryParameters["mtime_start"] = opts.ModifiedAfter.Unix()
+	}
+	if !opts.ModifiedBefore.IsZero() {
+		queryParameters["mtime_end"] = opts.ModifiedBefore.Unix()
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SearchSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SearchResponder(resp *http.Response) (result ListResponse, err error) {
+	var doc jsonSearchList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.Total = int(doc.Total.Int64())
+	result.Files = make([]FileInfo, len(doc.Datas))
+	for i, f := range doc.Datas {
+		result.Files[i] = newFileInfo(f.Path, f.jsonFileInfo)
+	}
+
+	return
+}
+
+// GetChecksum returns the MD5 checksum of the file p computed by the NAS.
+func (client Client) GetChecksum(ctx context.Context, p string) (result ChecksumResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetChecksum")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetChecksumPreparer(ctx, p)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetChecksum", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetChecksumSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetChecksum", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetChecksumResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "GetChecksum", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetChecksumPreparer(ctx context.Context, p string) (*http.Request, error) {
+	dir, name := path.Split(path.Clean(p))
+
+	queryParameters := map[string]interface{}{
+		"func":      "get_checksum",
+		"path":      autorest.Encode("query", dir),
+		"file_name": autorest.Encode("query", name),
+		"hash":      "md5",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetChecksumSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetChecksumResponder(resp *http.Response) (result ChecksumResponse, err error) {
+	var doc jsonChecksum
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingJSON(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if err = statusError(doc.Status); err != nil {
+		return
+	}
+
+	result.MD5 = strings.ToLower(doc.Checksum)
+
+	return
+}
+
+// MkdirAll creates the directory p along with any missing parents. It
+// returns nil if p already is a directory.
+func (client Client) MkdirAll(ctx context.Context, p string) error {
+	p = path.Clean(p)
+	info, err := client.Stat(ctx, p)
+	if err == nil {
+		if !info.IsDir {
+			return &StatusError{Status: StatusFileExists}
+		}
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if parent := path.Dir(p); parent != p && parent != "/" {
+		if err = client.MkdirAll(ctx, parent); err != nil {
+			return err
+		}
+	}
+
+	err = client.Mkdir(ctx, p)
+	if err != nil && errors.Is(err, os.ErrExist) {
+		// created concurrently
+		return nil
+	}
+	return err
+}
+
+// waitForTask polls the background task taskID until it has finished. If
+// ctx has no deadline, the wait is limited to taskTimeout.
+func (client Client) waitForTask(ctx context.Context, taskID string) (TaskStatusResponse, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, taskTimeout)
+		defer cancel()
+	}
+
+	for {
+		status, err := client.GetTaskStatus(ctx, taskID)
+		if err != nil {
+			return status, err
+		}
+
+		switch status.Status {
+		case TaskStatusFinished, TaskStatusFailed, TaskStatusCancelled:
+			if status.Status != TaskStatusFinished || len(status.Failures) > 0 {
+				return status, &OperationError{TaskID: taskID, Status: status.Status, Failures: status.Failures}
+			}
+			return status, nil
+		}
+
+		t := time.NewTimer(pollInterval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return status, ctx.Err()
+		}
+	}
+}
+
+func conflictMode(c ConflictPolicy) string {
+	switch c {
+	case ConflictSkip:
+		return "1"
+	case ConflictRename:
+		return "2"
+	}
+	return "0"
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,37 @@
+package filestationapi
+
+import (
+	"context"
+	"io"
+
+	"github.com/qnap/core-sdk-for-go/services/filestation"
+)
+
+// FileStationClientAPI contains the set of methods on the filestation.Client type.
+type FileStationClientAPI interface {
+	List(ctx context.Context, dir string, opts filestation.ListOptions) (filestation.ListResponse, error)
+	ListAll(ctx context.Context, dir string, opts filestation.ListOptions) (filestation.ListResponse, error)
+	Stat(ctx context.Context, p string) (filestation.StatResponse, error)
+	StartChunkedUpload(ctx context.Context, destDir string) (filestation.UploadSessionResponse, error)
+	GetUploadStatus(ctx context.Context, destDir, uploadID string) (filestation.UploadSessionResponse, error)
+	UploadChunk(ctx context.Context, opts filestation.UploadChunkOptions, chunk io.Reader, size int64) error
+	Upload(ctx context.Context, destDir, name string, r io.Reader, size int64, opts filestation.UploadOptions) (filestation.UploadResponse, error)
+	Download(ctx context.Context, p string, w io.Writer, opts filestation.DownloadOptions) (filestation.DownloadResponse, error)
+	Mkdir(ctx context.Context, p string) error
+	MkdirAll(ctx context.Context, p string) error
+	Rename(ctx context.Context, p, newName string) error
+	Copy(ctx context.Context, srcDir string, names []string, destDir string, opts filestation.TransferOptions, dontWait bool) (filestation.OperationResponse, error)
+	Move(ctx context.Context, srcDir string, names []string, destDir string, opts filestation.TransferOptions, dontWait bool) (filestation.OperationResponse, error)
+	Delete(ctx context.Context, dir string, names []string, permanent, dontWait bool) (filestation.OperationResponse, error)
+	GetTaskStatus(ctx context.Context, taskID string) (filestation.TaskStatusResponse, error)
+	CreateShareLink(ctx context.Context, p string, opts filestation.ShareLinkOptions) (filestation.ShareLinkResponse, error)
+	ListShareLinks(ctx context.Context) (filestation.ShareLinkListResponse, error)
+	RevokeShareLink(ctx context.Context, id string) error
+	Compress(ctx context.Context, srcDir string, names []string, archiveName string, opts filestation.CompressOptions, dontWait bool) (filestation.OperationResponse, error)
+	Extract(ctx context.Context, archive, destDir string, opts filestation.ExtractOptions, dontWait bool) (filestation.OperationResponse, error)
+	Search(ctx context.Context, dir string, opts filestation.SearchOptions) (filestation.ListResponse, error)
+	GetChecksum(ctx context.Context, p string) (filestation.ChecksumResponse, error)
+	Sync(ctx context.Context, localDir, remoteDir string, opts filestation.SyncOptions) (filestation.SyncResponse, error)
+}
+
+var _ FileStationClientAPI = (*filestation.Client)(nil)
@@ -0,0 +1,385 @@
+package filestation
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+// WritableFS extends fs.FS with the operations needed to modify a file
+// system.
+type WritableFS interface {
+	fs.FS
+	// Create creates or truncates the file name. The content is uploaded
+	// when the returned file is closed.
+	Create(name string) (WriterFile, error)
+	// Remove removes the file or empty directory name.
+	Remove(name string) error
+}
+
+// WriterFile is a file opened for writing by WritableFS.Create.
+type WriterFile interface {
+	io.Writer
+	io.Closer
+}
+
+// FS is a file system backed by File Station. It implements fs.FS,
+// fs.ReadDirFS, fs.StatFS and WritableFS, so shares of the NAS can be used
+// with fs.WalkDir, fs.Glob, template.ParseFS and similar functions.
+type FS struct {
+	client Client
+	ctx    context.Context
+	root   string
+
+	// Permanent makes Remove bypass the recycle bin.
+	Permanent bool
+}
+
+var (
+	_ fs.ReadDirFS = (*FS)(nil)
+	_ fs.StatFS    = (*FS)(nil)
+	_ WritableFS   = (*FS)(nil)
+)
+
+// NewFS returns a file system rooted at the directory root of the NAS, e.g.
+// "/Public". All requests are made using ctx.
+func NewFS(ctx context.Context, client Client, root string) *FS {
+	return &FS{client: client, ctx: ctx, root: path.Clean("/" + root)}
+}
+
+func (fsys *FS) path(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return path.Join(fsys.root, name), nil
+}
+
+// Open opens the file or directory name.
+func (fsys *FS) Open(name string) (fs.File, error) {
+	p, err := fsys.path("open", name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := fsys.stat(p, name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	if info.IsDir() {
+		return &dirFile{fsys: fsys, name: name, path: p, info: info}, nil
+	}
+	return &readFile{fsys: fsys, name: name, path: p, info: info}, nil
+}
+
+// Stat returns the fs.FileInfo of name.
+func (fsys *FS) Stat(name string) (fs.FileInfo, error) {
+	p, err := fsys.path("stat", name)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := fsys.stat(p, name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+	return info, nil
+}
+
+// ReadDir returns the entries of the directory name sorted by filename.
+func (fsys *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	p, err := fsys.path("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := fsys.readDir(p)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+// Create creates or truncates the file name. The content is buffered in a
+// temporary file and uploaded when the returned file is closed.
+func (fsys *FS) Create(name string) (WriterFile, error) {
+	p, err := fsys.path("create", name)
+	if err != nil {
+		return nil, err
+	}
+	if name == "." {
+		return nil, &fs.PathError{Op: "create", Path: name, Err: fs.ErrInvalid}
+	}
+
+	tmp, err := os.CreateTemp("", "filestation-*")
+	if err != nil {
+		return nil, &fs.PathError{Op: "create", Path: name, Err: err}
+	}
+	return &writeFile{fsys: fsys, name: name, path: p, tmp: tmp}, nil
+}
+
+// Remove removes the file or directory name.
+func (fsys *FS) Remove(name string) error {
+	p, err := fsys.path("remove", name)
+	if err != nil {
+		return err
+	}
+	if name == "." {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+
+	dir, base := path.Split(p)
+	if _, err = fsys.client.Delete(fsys.ctx, dir, []string{base}, fsys.Permanent, false); err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (fsys *FS) stat(p, name string) (fs.FileInfo, error) {
+	res, err := fsys.client.Stat(fsys.ctx, p)
+	if err != nil {
+		return nil, err
+	}
+
+	info := res.FileInfo
+	info.Name = path.Base(name)
+	return fileInfo{info}, nil
+}
+
+func (fsys *FS) readDir(p string) ([]fs.DirEntry, error) {
+	res, err := fsys.client.ListAll(fsys.ctx, p, ListOptions{Sort: SortByName, ShowHidden: true})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]fs.DirEntry, len(res.Files))
+	for i, f := range res.Files {
+		entries[i] = fs.FileInfoToDirEntry(fileInfo{f})
+	}
+	// File Station sorts case insensitively.
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// fileInfo implements fs.FileInfo.
+type fileInfo struct {
+	info FileInfo
+}
+
+func (fi fileInfo) Name() string       { return fi.info.Name }
+func (fi fileInfo) Size() int64        { return fi.info.Size }
+func (fi fileInfo) Mode() fs.FileMode  { return fi.info.Mode }
+func (fi fileInfo) ModTime() time.Time { return fi.info.ModTime }
+func (fi fileInfo) IsDir() bool        { return fi.info.IsDir }
+func (fi fileInfo) Sys() interface{}   { return fi.info }
+
+// dirFile implements fs.ReadDirFile.
+type dirFile struct {
+	fsys    *FS
+	name    string
+	path    string
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	read    bool
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *dirFile) Close() error { return nil }
+
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fsys.readDir(d.path)
+		if err != nil {
+			return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: err}
+		}
+		d.entries, d.read = entries, true
+	}
+
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+// readFile implements fs.File, io.Seeker and io.ReaderAt. The content is
+// streamed from the current offset on the first Read after opening or
+// seeking.
+type readFile struct {
+	fsys   *FS
+	name   string
+	path   string
+	info   fs.FileInfo
+	offset int64
+	body   *io.PipeReader
+	closed bool
+}
+
+func (f *readFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *readFile) Read(b []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.offset >= f.info.Size() {
+		return 0, io.EOF
+	}
+
+	if f.body == nil {
+		pr, pw := io.Pipe()
+		offset := f.offset
+		go func() {
+			_, err := f.fsys.client.Download(f.fsys.ctx, f.path, pw, DownloadOptions{Offset: offset})
+			pw.CloseWithError(err)
+		}()
+		f.body = pr
+	}
+
+	n, err := f.body.Read(b)
+	f.offset += int64(n)
+	if err != nil && err != io.EOF {
+		err = &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+	return n, err
+}
+
+func (f *readFile) Seek(offset int64, whence int) (int64, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrClosed}
+	}
+
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.info.Size()
+	}
+	if offset < 0 {
+		return 0, &fs.PathError{Op: "seek", Path: f.name, Err: fs.ErrInvalid}
+	}
+
+	if offset != f.offset {
+		f.stopBody()
+		f.offset = offset
+	}
+	return offset, nil
+}
+
+func (f *readFile) ReadAt(b []byte, off int64) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrClosed}
+	}
+	if off < 0 {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
+	}
+	if off >= f.info.Size() {
+		return 0, io.EOF
+	}
+
+	length := int64(len(b))
+	if rest := f.info.Size() - off; rest < length {
+		length = rest
+	}
+	if length == 0 {
+		return 0, nil
+	}
+
+	buf := &sliceWriter{b: b[:length]}
+	_, err := f.fsys.client.Download(f.fsys.ctx, f.path, buf, DownloadOptions{Offset: off, Length: length})
+	if err != nil {
+		return buf.n, &fs.PathError{Op: "read", Path: f.name, Err: err}
+	}
+	if buf.n < len(b) {
+		return buf.n, io.EOF
+	}
+	return buf.n, nil
+}
+
+func (f *readFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.stopBody()
+	f.closed = true
+	return nil
+}
+
+func (f *readFile) stopBody() {
+	if f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+}
+
+// sliceWriter writes into a fixed size slice.
+type sliceWriter struct {
+	b []byte
+	n int
+}
+
+func (w *sliceWriter) Write(p []byte) (int, error) {
+	n := copy(w.b[w.n:], p)
+	w.n += n
+	if n < len(p) {
+		return n, io.ErrShortWrite
+	}
+	return n, nil
+}
+
+// writeFile buffers the content written by FS.Create in a temporary file.
+type writeFile struct {
+	fsys   *FS
+	name   string
+	path   string
+	tmp    *os.File
+	closed bool
+}
+
+func (f *writeFile) Write(b []byte) (int, error) {
+	if f.closed {
+		return 0, &fs.PathError{Op: "write", Path: f.name, Err: fs.ErrClosed}
+	}
+	return f.tmp.Write(b)
+}
+
+// Close uploads the content and removes the temporary file.
+func (f *writeFile) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	defer os.Remove(f.tmp.Name())
+	defer f.tmp.Close()
+
+	size, err := f.tmp.Seek(0, io.SeekCurrent)
+	if err == nil {
+		_, err = f.tmp.Seek(0, io.SeekStart)
+	}
+	if err == nil {
+		dir, base := path.Split(f.path)
+		_, err = f.fsys.client.Upload(f.fsys.ctx, dir, base, f.tmp, size, UploadOptions{Conflict: ConflictOverwrite})
+	}
+	if err != nil {
+		return &fs.PathError{Op: "close", Path: f.name, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,553 @@
+package filestation
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/filestation"
+
+// Status codes reported by File Station.
+const (
+	StatusFailure            = 0
+	StatusSuccess            = 1
+	StatusFileExists         = 2
+	StatusAuthFailure        = 3
+	StatusPermissionDenied   = 4
+	StatusFileNotExist       = 5
+	StatusExtracting         = 6
+	StatusOpenFileFailure    = 7
+	StatusDisabled           = 8
+	StatusQuotaExceeded      = 9
+	StatusSrcPermissionDeny  = 10
+	StatusDestPermissionDeny = 11
+	StatusIllegalName        = 12
+	StatusParameterError     = 20
+	StatusDestFileNotExist   = 25
+	StatusFileNameTooLong    = 26
+)
+
+var statusMessages = map[int]string{
+	StatusFailure:            "operation failed",
+	StatusFileExists:         "file exists",
+	StatusAuthFailure:        "authentication failed",
+	StatusPermissionDenied:   "permission denied",
+	StatusFileNotExist:       "file does not exist",
+	StatusExtracting:         "archive is being extracted",
+	StatusOpenFileFailure:    "failed to open file",
+	StatusDisabled:           "File Station is disabled",
+	StatusQuotaExceeded:      "quota exceeded",
+	StatusSrcPermissionDeny:  "permission denied on source",
+	StatusDestPermissionDeny: "permission denied on destination",
+	StatusIllegalName:        "illegal file name",
+	StatusParameterError:     "invalid parameter",
+	StatusDestFileNotExist:   "destination does not exist",
+	StatusFileNameTooLong:    "file name too long",
+}
+
+// StatusError is returned if File Station reports a status other than
+// StatusSuccess.
+type StatusError struct {
+	Status int
+}
+
+func (e *StatusError) Error() string {
+	if msg, ok := statusMessages[e.Status]; ok {
+		return fmt.Sprintf("file station: %s (status %d)", msg, e.Status)
+	}
+	return fmt.Sprintf("file station: status %d", e.Status)
+}
+
+// Is maps the status to the errors of package os so that errors.Is(err,
+// os.ErrNotExist) and similar checks work.
+func (e *StatusError) Is(target error) bool {
+	switch target {
+	case os.ErrNotExist:
+		return e.Status == StatusFileNotExist || e.Status == StatusDestFileNotExist
+	case os.ErrExist:
+		return e.Status == StatusFileExists
+	case os.ErrPermission:
+		return e.Status == StatusPermissionDenied || e.Status == StatusSrcPermissionDeny || e.Status == StatusDestPermissionDeny
+	}
+	return false
+}
+
+// statusError returns a *StatusError unless status reports success. A
+// missing status is treated as success.
+func statusError(status *int) error {
+	if status == nil || *status == StatusSuccess {
+		return nil
+	}
+	return &StatusError{Status: *status}
+}
+
+type jsonStatus struct {
+	Status *int `json:"status"`
+}
+
+type jsonFileInfo struct {
+	Filename  string     `json:"filename"`
+	IsFolder  int        `json:"isfolder"`
+	Filesize  jsonString `json:"filesize"`
+	Owner     string     `json:"owner"`
+	Group     string     `json:"group"`
+	Privilege string     `json:"privilege"`
+	MT        string     `json:"mt"`
+	EpochMT   jsonString `json:"epochmt"`
+	Exist     *int       `json:"exist"`
+}
+
+type jsonFileList struct {
+	jsonStatus
+	Total jsonString     `json:"total"`
+	Datas []jsonFileInfo `json:"datas"`
+}
+
+// jsonString accepts JSON strings and numbers, as File Station reports
+// numeric values in either form.
+type jsonString string
+
+func (s *jsonString) UnmarshalJSON(data []byte) error {
+	v := strings.Trim(string(data), `"`)
+	if v == "null" {
+		v = ""
+	}
+	*s = jsonString(v)
+	return nil
+}
+
+func (s jsonString) Int64() int64 {
+	i, err := strconv.ParseInt(string(s), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+// FileInfo describes a file or directory on the NAS.
+type FileInfo struct {
+	Name    string      `xml:"name" json:"name" yaml:"name"`
+	Path    string      `xml:"path" json:"path" yaml:"path"`
+	Size    int64       `xml:"size" json:"size" yaml:"size"`
+	ModTime time.Time   `xml:"modTime" json:"modTime" yaml:"modTime"`
+	Owner   string      `xml:"owner" json:"owner" yaml:"owner"`
+	Group   string      `xml:"group" json:"group" yaml:"group"`
+	Mode    os.FileMode `xml:"mode" json:"mode" yaml:"mode"`
+	IsDir   bool        `xml:"isDir" json:"isDir" yaml:"isDir"`
+}
+
+func newFileInfo(dir string, f jsonFileInfo) FileInfo {
+	info := FileInfo{
+		Name:  f.Filename,
+		Path:  path.Join(dir, f.Filename),
+		Size:  f.Filesize.Int64(),
+		Owner: f.Owner,
+		Group: f.Group,
+		IsDir: f.IsFolder == 1,
+	}
+
+	if epoch := f.EpochMT.Int64(); epoch > 0 {
+		info.ModTime = time.Unix(epoch, 0)
+	} else if mt, err := time.ParseInLocation("2006/01/02 15:04:05", f.MT, time.Local); err == nil {
+		info.ModTime = mt
+	}
+
+	if perm, err := strconv.ParseUint(f.Privilege, 8, 32); err == nil {
+		info.Mode = os.FileMode(perm) & os.ModePerm
+	}
+	if info.IsDir {
+		info.Mode |= os.ModeDir
+	}
+
+	return info
+}
+
+// SortField selects the attribute directory listings are sorted by.
+type SortField string
+
+const (
+	SortByName      SortField = "filename"
+	SortBySize      SortField = "filesize"
+	SortByType      SortField = "filetype"
+	SortByModTime   SortField = "mt"
+	SortByOwner     SortField = "owner"
+	SortByGroup     SortField = "group"
+	SortByPrivilege SortField = "privilege"
+)
+
+// DefaultPageSize is the number of entries requested per page if
+// ListOptions.Limit is zero.
+const DefaultPageSize = 500
+
+// ListOptions controls paging and sorting of directory listings.
+type ListOptions struct {
+	// Start is the index of the first entry to return.
+	Start int
+	// Limit is the maximum number of entries to return. Defaults to DefaultPageSize.
+	Limit      int
+	Sort       SortField
+	Descending bool
+	ShowHidden bool
+}
+
+type ListResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// Total is the number of entries of the directory regardless of paging.
+	Total int
+	Files []FileInfo
+}
+
+type StatResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	FileInfo          `yaml:",inline"`
+}
+
+// ConflictPolicy controls what happens if the target of an upload exists.
+type ConflictPolicy string
+
+const (
+	// ConflictOverwrite replaces the existing file.
+	ConflictOverwrite ConflictPolicy = "overwrite"
+	// ConflictSkip keeps the existing file and skips the upload.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictRename uploads the file using a name like "name (1).ext".
+	ConflictRename ConflictPolicy = "rename"
+)
+
+// ProgressFunc is called with the number of bytes transferred so far and the
+// total number of bytes, which is -1 if unknown.
+type ProgressFunc func(transferred, total int64)
+
+// DefaultChunkSize is the size of upload chunks if UploadOptions.ChunkSize is zero.
+const DefaultChunkSize = 8 << 20
+
+// UploadOptions controls Client.Upload.
+type UploadOptions struct {
+	// Conflict defaults to ConflictOverwrite.
+	Conflict ConflictPolicy
+	// ChunkSize defaults to DefaultChunkSize.
+	ChunkSize int64
+	// UploadID resumes an interrupted upload. If the reader implements
+	// io.Seeker it is positioned at the resume offset, otherwise the bytes
+	// already uploaded are read and discarded.
+	UploadID string
+	// ResumeName is required with UploadID. It is the Name reported for the
+	// interrupted upload, which differs from the requested name if the file
+	// has been renamed because of ConflictRename.
+	ResumeName string
+	// ModTime sets the modification time of the uploaded file. The zero
+	// value keeps the time of the upload.
+	ModTime  time.Time
+	Progress ProgressFunc
+}
+
+type jsonUploadSession struct {
+	jsonStatus
+	UploadID string     `json:"upload_id"`
+	Size     jsonString `json:"size"`
+}
+
+type UploadSessionResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	UploadID          string
+	// Offset is the number of bytes already uploaded.
+	Offset int64
+}
+
+// UploadChunkOptions describes a single chunk of a chunked upload.
+type UploadChunkOptions struct {
+	UploadID  string
+	DestDir   string
+	Name      string
+	Overwrite bool
+	// FileSize is the size of the complete file.
+	FileSize int64
+	// Offset is the position of the chunk within the file.
+	Offset int64
+	// ModTime sets the modification time of the file if not zero.
+	ModTime time.Time
+}
+
+type UploadResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// UploadID and Name can be used to resume the upload if it failed.
+	UploadID string
+	// Name of the uploaded file within the destination directory.
+	Name string
+	// Path of the uploaded file, which differs from the requested path if
+	// the file has been renamed because of ConflictRename.
+	Path    string
+	Skipped bool
+}
+
+// DownloadOptions controls Client.Download.
+type DownloadOptions struct {
+	// Offset is the position to start downloading at, e.g. to resume a
+	// partial download.
+	Offset int64
+	// Length limits the number of bytes to download. Zero downloads up to the
+	// end of the file.
+	Length   int64
+	Progress ProgressFunc
+}
+
+type DownloadResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// Written is the number of bytes written.
+	Written int64
+}
+
+// TransferOptions controls Client.Copy and Client.Move.
+type TransferOptions struct {
+	// Conflict defaults to ConflictOverwrite.
+	Conflict ConflictPolicy
+}
+
+type jsonTask struct {
+	jsonStatus
+	PID jsonString `json:"pid"`
+}
+
+type OperationResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// TaskID identifies the background task, it is empty if the operation
+	// completed immediately.
+	TaskID string
+	// Failures lists the entries which could not be processed.
+	Failures []ItemError
+}
+
+// TaskStatus is the state of a background task.
+type TaskStatus string
+
+const (
+	TaskStatusRunning   TaskStatus = "running"
+	TaskStatusFinished  TaskStatus = "finished"
+	TaskStatusFailed    TaskStatus = "failed"
+	TaskStatusCancelled TaskStatus = "cancelled"
+)
+
+// parseTaskStatus maps the state reported by File Station to a TaskStatus.
+// Unknown states are an error rather than being treated as running, so a
+// wait cannot hang on a state it does not understand.
+func parseTaskStatus(s string) (TaskStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "running":
+		return TaskStatusRunning, nil
+	case "finished":
+		return TaskStatusFinished, nil
+	case "failed":
+		return TaskStatusFailed, nil
+	case "cancelled", "canceled":
+		return TaskStatusCancelled, nil
+	}
+	return "", fmt.Errorf("unknown task state %q", s)
+}
+
+type jsonTaskStatus struct {
+	jsonStatus
+	PID     jsonString `json:"pid"`
+	State   string     `json:"state"`
+	Percent jsonString `json:"percent"`
+	Failed  []struct {
+		Path   string `json:"path"`
+		Status int    `json:"status"`
+	} `json:"failed"`
+}
+
+type TaskStatusResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	TaskID            string
+	Status            TaskStatus
+	Percent           int
+	Failures          []ItemError
+}
+
+// ItemError reports the failure of a single entry of a background task.
+type ItemError struct {
+	Path string
+	Err  error
+}
+
+func (e ItemError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e ItemError) Unwrap() error {
+	return e.Err
+}
+
+// OperationError is returned if a background task failed or some of its
+// entries could not be processed.
+type OperationError struct {
+	TaskID   string
+	Status   TaskStatus
+	Failures []ItemError
+}
+
+func (e *OperationError) Error() string {
+	msg := fmt.Sprintf("file station: task %s %s", e.TaskID, e.Status)
+	if len(e.Failures) > 0 {
+		msg += fmt.Sprintf(" with %d failed entries: %s", len(e.Failures), e.Failures[0].Error())
+		if len(e.Failures) > 1 {
+			msg += ", ..."
+		}
+	}
+	return msg
+}
+
+// ShareLinkOptions controls Client.CreateShareLink.
+type ShareLinkOptions struct {
+	// Expires is the time the link stops working. The zero value creates a
+	// link which never expires.
+	Expires time.Time
+	// Password protects the link if set.
+	Password string
+	// AllowUpload allows uploading files into a shared directory.
+	AllowUpload bool
+	// Hostname is used in the URL of the link instead of the default
+	// hostname of the NAS, e.g. its myQNAPcloud or DDNS name.
+	Hostname string
+}
+
+type jsonShareLink struct {
+	SSID        string     `json:"ssid"`
+	LinkURL     string     `json:"link_url"`
+	Filename    string     `json:"filename"`
+	Path        string     `json:"path"`
+	ExpireTime  jsonString `json:"expire_time"`
+	CreateTime  jsonString `json:"create_time"`
+	AccessCode  int        `json:"access_code_enabled"`
+	Upload      int        `json:"access_enabled"`
+	AccessCount jsonString `json:"access_count"`
+}
+
+type jsonShareLinkList struct {
+	jsonStatus
+	Links []jsonShareLink `json:"datas"`
+}
+
+// ShareLink is a sharing link of a file or directory.
+type ShareLink struct {
+	ID      string    `xml:"id" json:"id" yaml:"id"`
+	URL     string    `xml:"url" json:"url" yaml:"url"`
+	Path    string    `xml:"path" json:"path" yaml:"path"`
+	Created time.Time `xml:"created" json:"created" yaml:"created"`
+	// Expires is zero if the link never expires.
+	Expires     time.Time `xml:"expires" json:"expires" yaml:"expires"`
+	HasPassword bool      `xml:"hasPassword" json:"hasPassword" yaml:"hasPassword"`
+	AllowUpload bool      `xml:"allowUpload" json:"allowUpload" yaml:"allowUpload"`
+	// AccessCount is the number of times the link has been opened.
+	AccessCount int64 `xml:"accessCount" json:"accessCount" yaml:"accessCount"`
+}
+
+func newShareLink(l jsonShareLink) ShareLink {
+	link := ShareLink{
+		ID:          l.SSID,
+		URL:         l.LinkURL,
+		Path:        path.Join(l.Path, l.Filename),
+		HasPassword: l.AccessCode == 1,
+		AllowUpload: l.Upload == 1,
+		AccessCount: l.AccessCount.Int64(),
+	}
+	if t := l.CreateTime.Int64(); t > 0 {
+		link.Created = time.Unix(t, 0)
+	}
+	if t := l.ExpireTime.Int64(); t > 0 {
+		link.Expires = time.Unix(t, 0)
+	}
+	return link
+}
+
+type ShareLinkResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	ShareLink         `yaml:",inline"`
+}
+
+type ShareLinkListResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Links             []ShareLink
+}
+
+// ArchiveFormat is the format of archives created by Client.Compress.
+type ArchiveFormat string
+
+const (
+	ArchiveZip      ArchiveFormat = "zip"
+	ArchiveSevenZip ArchiveFormat = "7z"
+)
+
+// CompressLevel trades compression ratio for speed.
+type CompressLevel string
+
+const (
+	CompressStore   CompressLevel = "store"
+	CompressFast    CompressLevel = "fast"
+	CompressNormal  CompressLevel = "normal"
+	CompressMaximum CompressLevel = "large"
+)
+
+// CompressOptions controls Client.Compress.
+type CompressOptions struct {
+	// Format defaults to ArchiveZip.
+	Format ArchiveFormat
+	// Level defaults to CompressNormal.
+	Level CompressLevel
+	// Password encrypts the archive using AES if set.
+	Password string
+	// Conflict controls what happens if the archive exists and defaults to
+	// ConflictOverwrite.
+	Conflict ConflictPolicy
+}
+
+// ExtractOptions controls Client.Extract.
+type ExtractOptions struct {
+	// Password of an encrypted archive.
+	Password string
+	// Conflict controls what happens if an extracted file exists and
+	// defaults to ConflictOverwrite.
+	Conflict ConflictPolicy
+}
+
+// SearchOptions controls Client.Search. Zero values disable the
+// corresponding filter.
+type SearchOptions struct {
+	// Pattern is matched against the names of the entries, e.g. "*.log".
+	Pattern        string
+	MinSize        int64
+	MaxSize        int64
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	// Start is the index of the first match to return.
+	Start int
+	// Limit is the maximum number of matches to return. Defaults to DefaultPageSize.
+	Limit int
+}
+
+type jsonSearchResult struct {
+	jsonFileInfo
+	Path string `json:"path"`
+}
+
+type jsonSearchList struct {
+	jsonStatus
+	Total jsonString         `json:"total"`
+	Datas []jsonSearchResult `json:"datas"`
+}
+
+type jsonChecksum struct {
+	jsonStatus
+	Checksum string `json:"checksum"`
+}
+
+type ChecksumResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// MD5 is the hex encoded MD5 checksum.
+	MD5 string
+}
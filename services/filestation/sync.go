@@ -0,0 +1,458 @@
+package filestation
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// SyncMode selects the direction of Client.Sync.
+type SyncMode int
+
+const (
+	// SyncUpload mirrors the local tree to the NAS.
+	SyncUpload SyncMode = iota
+	// SyncDownload mirrors the NAS tree to the local directory.
+	SyncDownload
+	// SyncTwoWay copies files missing on either side and replaces older
+	// files by newer ones. Deletions are not propagated, as they cannot be
+	// told apart from new files without keeping state between runs.
+	SyncTwoWay
+)
+
+// CompareMode selects how files existing on both sides are compared.
+type CompareMode int
+
+const (
+	// CompareSizeModTime treats files as equal if size and modification time
+	// match.
+	CompareSizeModTime CompareMode = iota
+	// CompareChecksum treats files as equal if size and MD5 checksum match.
+	// It is slower but independent of clocks and timestamps.
+	CompareChecksum
+)
+
+// DefaultModTimeWindow is the tolerance for modification times if
+// SyncOptions.ModTimeWindow is zero. File Station reports times in seconds.
+const DefaultModTimeWindow = 2 * time.Second
+
+// SyncOptions controls Client.Sync.
+type SyncOptions struct {
+	Mode    SyncMode
+	Compare CompareMode
+	// Delete removes files from the target which do not exist in the source.
+	// Directories are only removed once they are empty, so entries skipped
+	// by Include and Exclude are never deleted. It is ignored for
+	// SyncTwoWay. Remote files are moved to the recycle bin.
+	Delete bool
+	// DryRun reports the actions without performing them.
+	DryRun bool
+	// Include limits the sync to files matching one of the patterns if not
+	// empty. Exclude skips files and directories matching one of the
+	// patterns. Patterns use the syntax of path.Match and are matched
+	// against both the slash separated path relative to the root of the
+	// sync and the base name.
+	Include []string
+	Exclude []string
+	// ModTimeWindow defaults to DefaultModTimeWindow.
+	ModTimeWindow time.Duration
+	// Progress is called before each action is performed.
+	Progress func(SyncAction)
+}
+
+// SyncActionType is the kind of a SyncAction.
+type SyncActionType string
+
+const (
+	SyncActionUpload       SyncActionType = "upload"
+	SyncActionDownload     SyncActionType = "download"
+	SyncActionDeleteLocal  SyncActionType = "delete-local"
+	SyncActionDeleteRemote SyncActionType = "delete-remote"
+)
+
+// SyncAction is a single change made, or in dry-run mode planned, by
+// Client.Sync.
+type SyncAction struct {
+	Type SyncActionType `xml:"type" json:"type" yaml:"type"`
+	// Path is slash separated and relative to the root of the sync.
+	Path  string `xml:"path" json:"path" yaml:"path"`
+	Size  int64  `xml:"size" json:"size" yaml:"size"`
+	IsDir bool   `xml:"isDir" json:"isDir" yaml:"isDir"`
+}
+
+type SyncResponse struct {
+	// Actions lists the actions in the order they have been performed.
+	Actions []SyncAction
+	// Failures lists the actions which failed, keyed by path.
+	Failures []ItemError
+}
+
+// SyncError is returned by Client.Sync if some of the actions failed.
+type SyncError struct {
+	Failures []ItemError
+}
+
+func (e *SyncError) Error() string {
+	msg := fmt.Sprintf("file station: sync failed for %d entries: %s", len(e.Failures), e.Failures[0].Error())
+	if len(e.Failures) > 1 {
+		msg += ", ..."
+	}
+	return msg
+}
+
+type syncEntry struct {
+	size    int64
+	modTime time.Time
+	isDir   bool
+	// partial is set for directories containing entries skipped by the
+	// include and exclude patterns.
+	partial bool
+}
+
+// Sync compares the local directory localDir with the directory remoteDir on
+// the NAS and transfers or deletes only the files which differ. Failed
+// actions do not stop the sync; they are collected in the Failures of the
+// result and returned as a *SyncError. Empty directories are not
+// synchronized.
+func (client Client) Sync(ctx context.Context, localDir, remoteDir string, opts SyncOptions) (result SyncResponse, err error) {
+	if opts.ModTimeWindow == 0 {
+		opts.ModTimeWindow = DefaultModTimeWindow
+	}
+
+	local, err := walkLocal(localDir, opts)
+	if err != nil {
+		return
+	}
+	remote, err := walkRemote(NewFS(ctx, client, remoteDir), opts)
+	if err != nil {
+		return
+	}
+
+	s := syncer{client: client, ctx: ctx, localDir: localDir, remoteDir: path.Clean(remoteDir), opts: opts}
+	result.Actions, err = s.plan(local, remote)
+	if err != nil || opts.DryRun {
+		return
+	}
+
+	for _, a := range result.Actions {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		if opts.Progress != nil {
+			opts.Progress(a)
+		}
+		if aerr := s.apply(a, local, remote); aerr != nil {
+			result.Failures = append(result.Failures, ItemError{Path: a.Path, Err: aerr})
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		err = &SyncError{Failures: result.Failures}
+	}
+	return
+}
+
+func walkLocal(root string, opts SyncOptions) (map[string]syncEntry, error) {
+	entries := map[string]syncEntry{}
+	if _, err := os.Stat(root); errors.Is(err, fs.ErrNotExist) && opts.Mode != SyncUpload {
+		return entries, nil
+	}
+	return entries, walkFS(os.DirFS(root), entries, opts)
+}
+
+func walkRemote(fsys *FS, opts SyncOptions) (map[string]syncEntry, error) {
+	entries := map[string]syncEntry{}
+	if _, err := fsys.Stat("."); errors.Is(err, fs.ErrNotExist) && opts.Mode != SyncDownload {
+		return entries, nil
+	}
+	return entries, walkFS(fsys, entries, opts)
+}
+
+func walkFS(fsys fs.FS, entries map[string]syncEntry, opts SyncOptions) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p == "." {
+			return nil
+		}
+
+		skip := matchAny(opts.Exclude, p) ||
+			(!d.IsDir() && len(opts.Include) > 0 && !matchAny(opts.Include, p)) ||
+			(!d.IsDir() && !d.Type().IsRegular())
+		if skip {
+			// The parent keeps an entry the sync must not touch, so it
+			// must never be deleted.
+			if dir := path.Dir(p); dir != "." {
+				e := entries[dir]
+				e.partial = true
+				entries[dir] = e
+			}
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		entries[p] = syncEntry{size: info.Size(), modTime: info.ModTime(), isDir: d.IsDir()}
+		return nil
+	})
+}
+
+func matchAny(patterns []string, p string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(p)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+type syncer struct {
+	client    Client
+	ctx       context.Context
+	localDir  string
+	remoteDir string
+	opts      SyncOptions
+	// created caches remote directories known to exist.
+	created map[string]bool
+}
+
+// plan returns the actions needed to synchronize the trees. Files are
+// handled in path order. Directories which only exist in the target are
+// deleted after their contents, deepest first, and only if they end up
+// empty, so that entries skipped by the patterns are never deleted along
+// with their directory.
+func (s *syncer) plan(local, remote map[string]syncEntry) ([]SyncAction, error) {
+	var paths []string
+	for p := range local {
+		paths = append(paths, p)
+	}
+	for p := range remote {
+		if _, ok := local[p]; !ok {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	var actions, dirs []SyncAction
+	for _, p := range paths {
+		l, inLocal := local[p]
+		r, inRemote := remote[p]
+		var typ SyncActionType
+		size := l.size
+
+		switch {
+		case inLocal && !inRemote:
+			switch {
+			case s.opts.Mode == SyncDownload && s.opts.Delete:
+				typ = SyncActionDeleteLocal
+			case s.opts.Mode != SyncDownload && !l.isDir:
+				typ = SyncActionUpload
+			}
+		case !inLocal && inRemote:
+			size = r.size
+			switch {
+			case s.opts.Mode == SyncUpload && s.opts.Delete:
+				typ = SyncActionDeleteRemote
+			case s.opts.Mode != SyncUpload && !r.isDir:
+				typ = SyncActionDownload
+			}
+		case l.isDir || r.isDir:
+			if l.isDir != r.isDir {
+				return nil, fmt.Errorf("cannot sync %s: file and directory on either side", p)
+			}
+		default:
+			equal, err := s.equal(p, l, r)
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				break
+			}
+			switch s.opts.Mode {
+			case SyncUpload:
+				typ = SyncActionUpload
+			case SyncDownload:
+				typ, size = SyncActionDownload, r.size
+			case SyncTwoWay:
+				typ = SyncActionUpload
+				if r.modTime.After(l.modTime) {
+					typ, size = SyncActionDownload, r.size
+				}
+			}
+		}
+
+		if typ == "" {
+			continue
+		}
+		if (inLocal && l.isDir) || (!inLocal && r.isDir) {
+			dirs = append(dirs, SyncAction{Type: typ, Path: p, IsDir: true})
+			continue
+		}
+		actions = append(actions, SyncAction{Type: typ, Path: p, Size: size})
+	}
+
+	// Deepest first; a directory is kept if it contains skipped entries or
+	// a kept directory.
+	kept := map[string]bool{}
+	for p, e := range local {
+		if e.partial {
+			kept[p] = true
+		}
+	}
+	for p, e := range remote {
+		if e.partial {
+			kept[p] = true
+		}
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		a := dirs[i]
+		if kept[a.Path] {
+			kept[path.Dir(a.Path)] = true
+			continue
+		}
+		actions = append(actions, a)
+	}
+
+	return actions, nil
+}
+
+func (s *syncer) equal(p string, l, r syncEntry) (bool, error) {
+	if l.size != r.size {
+		return false, nil
+	}
+
+	if s.opts.Compare == CompareChecksum {
+		sum, err := localMD5(filepath.Join(s.localDir, filepath.FromSlash(p)))
+		if err != nil {
+			return false, err
+		}
+		res, err := s.client.GetChecksum(s.ctx, path.Join(s.remoteDir, p))
+		if err != nil {
+			return false, err
+		}
+		return sum == res.MD5, nil
+	}
+
+	d := l.modTime.Sub(r.modTime)
+	if d < 0 {
+		d = -d
+	}
+	return d <= s.opts.ModTimeWindow, nil
+}
+
+func (s *syncer) apply(a SyncAction, local, remote map[string]syncEntry) error {
+	localPath := filepath.Join(s.localDir, filepath.FromSlash(a.Path))
+	remotePath := path.Join(s.remoteDir, a.Path)
+
+	switch a.Type {
+	case SyncActionUpload:
+		return s.upload(localPath, remotePath, local[a.Path])
+	case SyncActionDownload:
+		return s.download(remotePath, localPath, remote[a.Path])
+	case SyncActionDeleteLocal:
+		// os.Remove fails for directories which are not empty, e.g. because
+		// deleting one of their files failed.
+		return os.Remove(localPath)
+	case SyncActionDeleteRemote:
+		if a.IsDir {
+			// File Station deletes directories recursively.
+			res, err := s.client.List(s.ctx, remotePath, ListOptions{Limit: 1, ShowHidden: true})
+			if err != nil {
+				return err
+			}
+			if res.Total > 0 || len(res.Files) > 0 {
+				return fmt.Errorf("directory %s is not empty", remotePath)
+			}
+		}
+		dir, name := path.Split(remotePath)
+		_, err := s.client.Delete(s.ctx, dir, []string{name}, false, false)
+		return err
+	}
+	return nil
+}
+
+func (s *syncer) upload(localPath, remotePath string, e syncEntry) error {
+	dir, name := path.Split(remotePath)
+	dir = path.Clean(dir)
+	if !s.created[dir] {
+		if err := s.client.MkdirAll(s.ctx, dir); err != nil {
+			return err
+		}
+		if s.created == nil {
+			s.created = map[string]bool{}
+		}
+		s.created[dir] = true
+	}
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = s.client.Upload(s.ctx, dir, name, f, e.size, UploadOptions{Conflict: ConflictOverwrite, ModTime: e.modTime})
+	return err
+}
+
+// download writes to a temporary file which replaces localPath once the
+// download has completed.
+func (s *syncer) download(remotePath, localPath string, e syncEntry) error {
+	dir := filepath.Dir(localPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(localPath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = s.client.Download(s.ctx, remotePath, tmp, DownloadOptions{})
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	if err = os.Chtimes(tmp.Name(), e.modTime, e.modTime); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), localPath)
+}
+
+func localMD5(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
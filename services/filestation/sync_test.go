@@ -0,0 +1,234 @@
+package filestation
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+var syncTestTime = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func syncTestFile(data string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(data), ModTime: syncTestTime}
+}
+
+// syncTestSource is the tree to mirror, syncTestTarget contains the same
+// file a.txt plus entries missing in the source, some of which are skipped
+// by the patterns of the test cases.
+func syncTestSource() fstest.MapFS {
+	return fstest.MapFS{
+		"a.txt":        syncTestFile("a"),
+		"logs/new.log": syncTestFile("new"),
+	}
+}
+
+func syncTestTarget() fstest.MapFS {
+	return fstest.MapFS{
+		"a.txt":         syncTestFile("a"),
+		"extra.txt":     syncTestFile("extra"),
+		"docs/keep.cfg": syncTestFile("keep"),
+		"docs/old.txt":  syncTestFile("old"),
+		"build/x.o":     syncTestFile("x"),
+		"build/sub/y.o": syncTestFile("y"),
+	}
+}
+
+func planSync(t *testing.T, local, remote fstest.MapFS, opts SyncOptions) []SyncAction {
+	t.Helper()
+
+	localEntries := map[string]syncEntry{}
+	if err := walkFS(local, localEntries, opts); err != nil {
+		t.Fatal(err)
+	}
+	remoteEntries := map[string]syncEntry{}
+	if err := walkFS(remote, remoteEntries, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	s := syncer{opts: opts}
+	actions, err := s.plan(localEntries, remoteEntries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return actions
+}
+
+func TestSyncPlanDelete(t *testing.T) {
+	size := func(p string) int64 {
+		if f, ok := syncTestSource()[p]; ok {
+			return int64(len(f.Data))
+		}
+		return int64(len(syncTestTarget()[p].Data))
+	}
+	upload := func(p string) SyncAction { return SyncAction{Type: SyncActionUpload, Path: p, Size: size(p)} }
+	download := func(p string) SyncAction { return SyncAction{Type: SyncActionDownload, Path: p, Size: size(p)} }
+	deleteRemote := func(p string, dir bool) SyncAction {
+		if dir {
+			return SyncAction{Type: SyncActionDeleteRemote, Path: p, IsDir: true}
+		}
+		return SyncAction{Type: SyncActionDeleteRemote, Path: p, Size: size(p)}
+	}
+	deleteLocal := func(p string, dir bool) SyncAction {
+		if dir {
+			return SyncAction{Type: SyncActionDeleteLocal, Path: p, IsDir: true}
+		}
+		return SyncAction{Type: SyncActionDeleteLocal, Path: p, Size: size(p)}
+	}
+
+	tests := []struct {
+		name    string
+		mode    SyncMode
+		include []string
+		exclude []string
+		want    []SyncAction
+	}{
+		{
+			name: "upload",
+			mode: SyncUpload,
+			want: []SyncAction{
+				deleteRemote("build/sub/y.o", false),
+				deleteRemote("build/x.o", false),
+				deleteRemote("docs/keep.cfg", false),
+				deleteRemote("docs/old.txt", false),
+				deleteRemote("extra.txt", false),
+				upload("logs/new.log"),
+				deleteRemote("docs", true),
+				deleteRemote("build/sub", true),
+				deleteRemote("build", true),
+			},
+		},
+		{
+			name:    "upload exclude",
+			mode:    SyncUpload,
+			exclude: []string{"*.cfg", "sub"},
+			want: []SyncAction{
+				deleteRemote("build/x.o", false),
+				deleteRemote("docs/old.txt", false),
+				deleteRemote("extra.txt", false),
+				upload("logs/new.log"),
+			},
+		},
+		{
+			name:    "upload include",
+			mode:    SyncUpload,
+			include: []string{"*.txt"},
+			want: []SyncAction{
+				deleteRemote("docs/old.txt", false),
+				deleteRemote("extra.txt", false),
+			},
+		},
+		{
+			name: "download",
+			mode: SyncDownload,
+			want: []SyncAction{
+				deleteLocal("build/sub/y.o", false),
+				deleteLocal("build/x.o", false),
+				deleteLocal("docs/keep.cfg", false),
+				deleteLocal("docs/old.txt", false),
+				deleteLocal("extra.txt", false),
+				download("logs/new.log"),
+				deleteLocal("docs", true),
+				deleteLocal("build/sub", true),
+				deleteLocal("build", true),
+			},
+		},
+		{
+			name:    "download exclude",
+			mode:    SyncDownload,
+			exclude: []string{"*.cfg", "sub"},
+			want: []SyncAction{
+				deleteLocal("build/x.o", false),
+				deleteLocal("docs/old.txt", false),
+				deleteLocal("extra.txt", false),
+				download("logs/new.log"),
+			},
+		},
+		{
+			name:    "download include",
+			mode:    SyncDownload,
+			include: []string{"*.txt"},
+			want: []SyncAction{
+				deleteLocal("docs/old.txt", false),
+				deleteLocal("extra.txt", false),
+			},
+		},
+		{
+			name: "two-way",
+			mode: SyncTwoWay,
+			want: []SyncAction{
+				download("build/sub/y.o"),
+				download("build/x.o"),
+				download("docs/keep.cfg"),
+				download("docs/old.txt"),
+				download("extra.txt"),
+				upload("logs/new.log"),
+			},
+		},
+		{
+			name:    "two-way exclude",
+			mode:    SyncTwoWay,
+			exclude: []string{"*.cfg", "sub"},
+			want: []SyncAction{
+				download("build/x.o"),
+				download("docs/old.txt"),
+				download("extra.txt"),
+				upload("logs/new.log"),
+			},
+		},
+		{
+			name:    "two-way include",
+			mode:    SyncTwoWay,
+			include: []string{"*.txt"},
+			want: []SyncAction{
+				download("docs/old.txt"),
+				download("extra.txt"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := SyncOptions{
+				Mode:          tt.mode,
+				Delete:        true,
+				Include:       tt.include,
+				Exclude:       tt.exclude,
+				ModTimeWindow: DefaultModTimeWindow,
+			}
+
+			// The target of a download is the local tree.
+			local, remote := syncTestSource(), syncTestTarget()
+			if tt.mode == SyncDownload {
+				local, remote = syncTestTarget(), syncTestSource()
+			}
+
+			got := planSync(t, local, remote, opts)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncPlanChanged(t *testing.T) {
+	local := fstest.MapFS{
+		"same.txt":  syncTestFile("same"),
+		"size.txt":  syncTestFile("local"),
+		"newer.txt": &fstest.MapFile{Data: []byte("new"), ModTime: syncTestTime.Add(time.Hour)},
+	}
+	remote := fstest.MapFS{
+		"same.txt":  &fstest.MapFile{Data: []byte("same"), ModTime: syncTestTime.Add(time.Second)},
+		"size.txt":  syncTestFile("remote!"),
+		"newer.txt": syncTestFile("old"),
+	}
+
+	got := planSync(t, local, remote, SyncOptions{Mode: SyncUpload, ModTimeWindow: DefaultModTimeWindow})
+	want := []SyncAction{
+		{Type: SyncActionUpload, Path: "newer.txt", Size: 3},
+		{Type: SyncActionUpload, Path: "size.txt", Size: 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("plan() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,341 @@
+package filestation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+// Upload streams size bytes read from r into the file name within the
+// directory destDir. The file is uploaded in chunks of opts.ChunkSize bytes,
+// so that only a single chunk has to be sent again if the connection fails.
+// An interrupted upload is resumed by passing the UploadID and the Name of
+// the result of the failed upload in opts.
+func (client Client) Upload(ctx context.Context, destDir, name string, r io.Reader, size int64, opts UploadOptions) (result UploadResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Upload")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	if size < 0 {
+		err = fmt.Errorf("upload of %s requires the size of the file", name)
+		return
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	conflict := opts.Conflict
+	if conflict == "" {
+		conflict = ConflictOverwrite
+	}
+
+	if opts.UploadID != "" {
+		// the interrupted upload may have been renamed by ConflictRename
+		if opts.ResumeName == "" {
+			err = fmt.Errorf("resuming the upload of %s requires the name of the interrupted upload", name)
+			return
+		}
+		name = opts.ResumeName
+	}
+
+	result.Name = name
+	result.Path = path.Join(destDir, name)
+	var offset int64
+	if opts.UploadID != "" {
+		var status UploadSessionResponse
+		status, err = client.GetUploadStatus(ctx, destDir, opts.UploadID)
+		if err != nil {
+			return
+		}
+
+		result.UploadID = opts.UploadID
+		offset = status.Offset
+		if err = skip(r, offset); err != nil {
+			return
+		}
+	} else {
+		if conflict != ConflictOverwrite {
+			var exists bool
+			exists, err = client.exists(ctx, result.Path)
+			if err != nil {
+				return
+			}
+
+			if exists && conflict == ConflictSkip {
+				result.Skipped = true
+				return
+			}
+			if exists && conflict == ConflictRename {
+				if name, err = client.uniqueName(ctx, destDir, name); err != nil {
+					return
+				}
+				result.Name = name
+				result.Path = path.Join(destDir, name)
+			}
+		}
+
+		var session UploadSessionResponse
+		session, err = client.StartChunkedUpload(ctx, destDir)
+		if err != nil {
+			return
+		}
+		result.UploadID = session.UploadID
+	}
+
+	if opts.Progress != nil {
+		opts.Progress(offset, size)
+	}
+
+	for offset < size || size == 0 {
+		n := chunkSize
+		if size-offset < n {
+			n = size - offset
+		}
+
+		err = client.UploadChunk(ctx, UploadChunkOptions{
+			UploadID:  result.UploadID,
+			DestDir:   destDir,
+			Name:      name,
+			Overwrite: conflict == ConflictOverwrite,
+			FileSize:  size,
+			Offset:    offset,
+			ModTime:   opts.ModTime,
+		}, io.LimitReader(r, n), n)
+		if err != nil {
+			return
+		}
+
+		offset += n
+		if opts.Progress != nil {
+			opts.Progress(offset, size)
+		}
+		if size == 0 {
+			break
+		}
+	}
+
+	return
+}
+
+// Download streams the file p into w. Use opts.Offset and opts.Length to
+// download a range of the file, e.g. to resume an interrupted download.
+func (client Client) Download(ctx context.Context, p string, w io.Writer, opts DownloadOptions) (result DownloadResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Download")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DownloadPreparer(ctx, p, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Download", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DownloadSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Download", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.DownloadResponder(resp, w, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "filestation.Client", "Download", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) DownloadPreparer(ctx context.Context, p string, opts DownloadOptions) (*http.Request, error) {
+	dir, name := path.Split(path.Clean(p))
+	queryParameters := map[string]interface{}{
+		"func":         "download",
+		"isfolder":     "0",
+		"source_total": "1",
+		"source_path":  autorest.Encode("query", dir),
+		"source_file":  autorest.Encode("query", name),
+	}
+
+	decorators := []autorest.PrepareDecorator{
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI),
+		autorest.WithQueryParameters(queryParameters),
+	}
+	if opts.Offset > 0 || opts.Length > 0 {
+		end := ""
+		if opts.Length > 0 {
+			end = strconv.FormatInt(opts.Offset+opts.Length-1, 10)
+		}
+		decorators = append(decorators, autorest.WithHeader("Range", fmt.Sprintf("bytes=%d-%s", opts.Offset, end)))
+	}
+
+	preparer := autorest.CreatePreparer(decorators...)
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) DownloadSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+// DownloadResponder copies the body of the response into w. File Station
+// reports errors as JSON documents instead of the file content.
+func (client Client) DownloadResponder(resp *http.Response, w io.Writer, opts DownloadOptions) (result DownloadResponse, err error) {
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK, http.StatusPartialContent))
+	if err != nil {
+		return
+	}
+	defer resp.Body.Close()
+
+	result.Response = autorest.Response{Response: resp}
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json") {
+		var doc jsonStatus
+		if err = json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+			return
+		}
+		err = statusError(doc.Status)
+		return
+	}
+
+	// a range has been requested, so the whole file must not be written
+	if (opts.Offset > 0 || opts.Length > 0) && resp.StatusCode != http.StatusPartialContent {
+		err = fmt.Errorf("range requests are not supported")
+		return
+	}
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = opts.Offset + resp.ContentLength
+	}
+
+	var dst io.Writer = w
+	if opts.Progress != nil {
+		dst = &progressWriter{w: w, transferred: opts.Offset, total: total, progress: opts.Progress}
+	}
+
+	result.Written, err = io.Copy(dst, resp.Body)
+	return
+}
+
+// exists reports whether the file p exists.
+func (client Client) exists(ctx context.Context, p string) (bool, error) {
+	_, err := client.Stat(ctx, p)
+	if err == nil {
+		return true, nil
+	}
+
+	var serr *StatusError
+	if errors.As(err, &serr) && serr.Status == StatusFileNotExist {
+		return false, nil
+	}
+	return false, err
+}
+
+// uniqueName returns the first name of the form "name (n).ext" which does not
+// exist in the directory dir.
+func (client Client) uniqueName(ctx context.Context, dir, name string) (string, error) {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 1; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)%s", base, i, ext)
+		exists, err := client.exists(ctx, path.Join(dir, candidate))
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+}
+
+// skip advances r by n bytes.
+func skip(r io.Reader, n int64) error {
+	if n == 0 {
+		return nil
+	}
+	if s, ok := r.(io.Seeker); ok {
+		_, err := s.Seek(n, io.SeekCurrent)
+		return err
+	}
+	_, err := io.CopyN(ioutil.Discard, r, n)
+	return err
+}
+
+type progressWriter struct {
+	w           io.Writer
+	transferred int64
+	total       int64
+	progress    ProgressFunc
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.transferred += int64(n)
+	pw.progress(pw.transferred, pw.total)
+	return n, err
+}
+
+// withMultipartFile streams r as the file part field of a multipart form. If
+// size is known the Content-Length of the request is set, otherwise the
+// request is sent chunked.
+func withMultipartFile(field, filename string, r io.Reader, size int64) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(req *http.Request) (*http.Request, error) {
+			req, err := p.Prepare(req)
+			if err != nil {
+				return req, err
+			}
+
+			var head bytes.Buffer
+			mw := multipart.NewWriter(&head)
+			if _, err = mw.CreateFormFile(field, filename); err != nil {
+				return req, err
+			}
+			headLen := head.Len()
+			if err = mw.Close(); err != nil {
+				return req, err
+			}
+			tail := append([]byte(nil), head.Bytes()[headLen:]...)
+			head.Truncate(headLen)
+
+			if req.Header == nil {
+				req.Header = make(http.Header)
+			}
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			req.Body = ioutil.NopCloser(io.MultiReader(&head, r, bytes.NewReader(tail)))
+			req.ContentLength = -1
+			if size >= 0 {
+				req.ContentLength = int64(headLen) + size + int64(len(tail))
+			}
+
+			return req, nil
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package firmware
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+	"github.com/qnap/core-sdk-for-go/services/auth"
+	"github.com/qnap/core-sdk-for-go/services/power"
+)
+
+const (
+	// versionPollInterval is the time between two boot status requests
+	// while waiting for the new firmware version.
+	versionPollInterval = 10 * time.Second
+	// versionTimeout is the maximum time to wait for the NAS to report the
+	// version of the image once it is back.
+	versionTimeout = 5 * time.Minute
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// CheckLiveUpdate returns the result of the live update check reported by
+// the NAS.
+func (client Client) CheckLiveUpdate(ctx context.Context) (result LiveUpdateResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.CheckLiveUpdate")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CheckLiveUpdatePreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "CheckLiveUpdate", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CheckLiveUpdateSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "firmware.Client", "CheckLiveUpdate", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.CheckLiveUpdateResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "CheckLiveUpdate", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CheckLiveUpdatePreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc":     "firm_update",
+		"live_update": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) CheckLiveUpdateSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) CheckLiveUpdateResponder(resp *http.Response) (result LiveUpdateResponse, err error) {
+	var doc qdocLiveUpdate
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	content := doc.Func.OwnContent
+	result.LiveUpdateInfo = LiveUpdateInfo{
+		CurrentVersion:  filterNullString(content.CurrentVersion),
+		CurrentBuild:    filterNullString(content.CurrentBuild),
+		LatestVersion:   filterNullString(content.NewVersion),
+		LatestBuild:     filterNullString(content.NewBuild),
+		ReleaseNotesURL: filterNullString(content.ReleaseNote),
+		DownloadURL:     filterNullString(content.DownloadURL),
+		CheckTime:       filterNullString(content.CheckTime),
+	}
+	result.UpdateAvailable = result.LatestBuild != "" &&
+		(result.LatestVersion != result.CurrentVersion || result.LatestBuild != result.CurrentBuild)
+
+	return
+}
+
+// Upload streams the firmware image to the NAS without buffering it in
+// memory. size is the length of the image in bytes or -1 if unknown.
+func (client Client) Upload(ctx context.Context, filename string, image io.Reader, size int64) (result UploadResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Upload")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.UploadPreparer(ctx, filename, image, size)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "Upload", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.UploadSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "firmware.Client", "Upload", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.UploadResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "Upload", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) UploadPreparer(ctx context.Context, filename string, image io.Reader, size int64) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "firm_update",
+		"upload":  "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters),
+		withMultipartFile("fwFile", filename, image, size))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// UploadSender sends the request without retries, as the image is streamed
+// and cannot be sent a second time.
+func (client Client) UploadSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) UploadResponder(resp *http.Response) (result UploadResponse, err error) {
+	var doc qdocFirmwareOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if err = resultError(doc.Result, doc.Message); err != nil {
+		return
+	}
+
+	result.ImageInfo = ImageInfo{
+		Version: filterNullString(doc.Func.OwnContent.Version),
+		Build:   filterNullString(doc.Func.OwnContent.Build),
+		Model:   filterNullString(doc.Func.OwnContent.Model),
+	}
+
+	return
+}
+
+// Apply starts the update with the previously uploaded firmware image. The
+// NAS reboots once the image has been installed.
+func (client Client) Apply(ctx context.Context) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Apply")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ApplyPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "Apply", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ApplySender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "Apply", resp, "Failure sending request")
+		return
+	}
+
+	err = client.ApplyResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "firmware.Client", "Apply", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ApplyPreparer(ctx context.Context) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"firm_update"},
+			"apply":   []string{"1"},
+			"reboot":  []string{"1"},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// ApplySender sends the request without retries, as the NAS may already be
+// installing the image when the response is lost.
+func (client Client) ApplySender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) ApplyResponder(resp *http.Response) (err error) {
+	var doc qdocFirmwareOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return resultError(doc.Result, doc.Message)
+}
+
+// Update uploads the firmware image, applies it and, unless dontWait is set,
+// waits through the reboot until the NAS reports the version of the image.
+// Waiting requires the NAS to report the version and build of the uploaded
+// image; otherwise the image is not applied and an error is returned.
+func (client Client) Update(ctx context.Context, filename string, image io.Reader, size int64, dontWait bool) (result UpdateResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Update")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	upload, err := client.Upload(ctx, filename, image, size)
+	result.Response = upload.Response
+	if err != nil {
+		return
+	}
+
+	pc := power.NewClientWithBaseURI(client.BaseURI)
+	pc.Client = client.Client
+	var before auth.ShutdownInfo
+	if !dontWait {
+		if upload.Version == "" || upload.Build == "" {
+			err = fmt.Errorf("firmware update: NAS reported no version and build for image %s to wait for", filename)
+			return
+		}
+		if before, err = pc.LastShutdown(ctx); err != nil {
+			return
+		}
+	}
+
+	if err = client.Apply(ctx); err != nil {
+		return
+	}
+
+	if dontWait {
+		return
+	}
+
+	if err = pc.WaitForReboot(ctx, before); err != nil {
+		return
+	}
+
+	// The NAS may still report the previous firmware for a short while after
+	// it has finished booting.
+	status := auth.NewClientWithBaseURI(client.BaseURI)
+	status.Client = client.Client
+	deadline := time.Now().Add(versionTimeout)
+	for {
+		var stat auth.BootStatusResponse
+		stat, err = status.GetBootStatus(ctx)
+		if err != nil {
+			return
+		}
+
+		result.Version = stat.FirmwareVersion
+		result.Build = stat.FirmwareBuild
+		if upload.Version == stat.FirmwareVersion && upload.Build == stat.FirmwareBuild {
+			return
+		}
+
+		if !time.Now().Before(deadline) {
+			err = fmt.Errorf("firmware update failed: NAS reports version %s build %s instead of %s build %s",
+				stat.FirmwareVersion, stat.FirmwareBuild, upload.Version, upload.Build)
+			return
+		}
+
+		t := time.NewTimer(versionPollInterval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			err = ctx.Err()
+			return
+		}
+	}
+}
+
+// withMultipartFile streams r as the file part field of a multipart form. If
+// size is known the Content-Length of the request is set, otherwise the
+// request is sent chunked.
+func withMultipartFile(field, filename string, r io.Reader, size int64) autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(req *http.Request) (*http.Request, error) {
+			req, err := p.Prepare(req)
+			if err != nil {
+				return req, err
+			}
+
+			var head bytes.Buffer
+			mw := multipart.NewWriter(&head)
+			if _, err = mw.CreateFormFile(field, filename); err != nil {
+				return req, err
+			}
+			headLen := head.Len()
+			if err = mw.Close(); err != nil {
+				return req, err
+			}
+			tail := append([]byte(nil), head.Bytes()[headLen:]...)
+			head.Truncate(headLen)
+
+			if req.Header == nil {
+				req.Header = make(http.Header)
+			}
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			req.Body = ioutil.NopCloser(io.MultiReader(&head, r, bytes.NewReader(tail)))
+			req.ContentLength = -1
+			if size >= 0 {
+				req.ContentLength = int64(headLen) + size + int64(len(tail))
+			}
+
+			return req, nil
+		})
+	}
+}
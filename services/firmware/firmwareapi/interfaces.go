@@ -0,0 +1,18 @@
+package firmwareapi
+
+import (
+	"context"
+	"io"
+
+	"github.com/qnap/core-sdk-for-go/services/firmware"
+)
+
+// FirmwareClientAPI contains the set of methods on the firmware.Client type.
+type FirmwareClientAPI interface {
+	CheckLiveUpdate(ctx context.Context) (firmware.LiveUpdateResponse, error)
+	Upload(ctx context.Context, filename string, image io.Reader, size int64) (firmware.UploadResponse, error)
+	Apply(ctx context.Context) error
+	Update(ctx context.Context, filename string, image io.Reader, size int64, dontWait bool) (firmware.UpdateResponse, error)
+}
+
+var _ FirmwareClientAPI = (*firmware.Client)(nil)
@@ -0,0 +1,148 @@
+package firmware
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/firmware"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+type qdocLiveUpdate struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		Name       string `xml:"name"`
+		OwnContent struct {
+			CurrentVersion string `xml:"current_version"`
+			CurrentBuild   string `xml:"current_build"`
+			NewVersion     string `xml:"new_version"`
+			NewBuild       string `xml:"new_build"`
+			ReleaseNote    string `xml:"release_note"`
+			DownloadURL    string `xml:"download_url"`
+			CheckTime      string `xml:"check_time"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocFirmwareOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+	Message    string   `xml:"msg"`
+	Func       struct {
+		OwnContent struct {
+			Version string `xml:"fw_version"`
+			Build   string `xml:"fw_build"`
+			Model   string `xml:"fw_model"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+// LiveUpdateInfo contains the result of the last check of the QNAP live
+// update server performed by the NAS.
+type LiveUpdateInfo struct {
+	CurrentVersion  string `xml:"currentVersion" json:"currentVersion" yaml:"currentVersion"`
+	CurrentBuild    string `xml:"currentBuild" json:"currentBuild" yaml:"currentBuild"`
+	LatestVersion   string `xml:"latestVersion,omitempty" json:"latestVersion,omitempty" yaml:"latestVersion,omitempty"`
+	LatestBuild     string `xml:"latestBuild,omitempty" json:"latestBuild,omitempty" yaml:"latestBuild,omitempty"`
+	UpdateAvailable bool   `xml:"updateAvailable" json:"updateAvailable" yaml:"updateAvailable"`
+	ReleaseNotesURL string `xml:"releaseNotesURL,omitempty" json:"releaseNotesURL,omitempty" yaml:"releaseNotesURL,omitempty"`
+	DownloadURL     string `xml:"downloadURL,omitempty" json:"downloadURL,omitempty" yaml:"downloadURL,omitempty"`
+	CheckTime       string `xml:"checkTime,omitempty" json:"checkTime,omitempty" yaml:"checkTime,omitempty"`
+}
+
+type LiveUpdateResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	LiveUpdateInfo    `yaml:",inline"`
+}
+
+// ImageInfo describes an uploaded firmware image.
+type ImageInfo struct {
+	Version string `xml:"version" json:"version" yaml:"version"`
+	Build   string `xml:"build" json:"build" yaml:"build"`
+	Model   string `xml:"model,omitempty" json:"model,omitempty" yaml:"model,omitempty"`
+}
+
+type UploadResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	ImageInfo         `yaml:",inline"`
+}
+
+type UpdateResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// Version and Build are reported by the NAS after the update. They are
+	// empty if the update has not been waited for.
+	Version string `xml:"version" json:"version" yaml:"version"`
+	Build   string `xml:"build" json:"build" yaml:"build"`
+}
+
+// IncompatibleImageError is returned if the firmware image does not match
+// the model of the NAS or is not a valid QTS image.
+type IncompatibleImageError struct {
+	Code    string
+	Message string
+}
+
+func (e *IncompatibleImageError) Error() string {
+	if e.Message == "" {
+		return "incompatible firmware image"
+	}
+	return "incompatible firmware image: " + e.Message
+}
+
+// InsufficientSpaceError is returned if the NAS lacks the space required to
+// store or unpack the firmware image.
+type InsufficientSpaceError struct {
+	Code    string
+	Message string
+}
+
+func (e *InsufficientSpaceError) Error() string {
+	if e.Message == "" {
+		return "insufficient space for firmware update"
+	}
+	return "insufficient space for firmware update: " + e.Message
+}
+
+// UpdateError is returned for all other failures reported by the NAS.
+type UpdateError struct {
+	Code    string
+	Message string
+}
+
+func (e *UpdateError) Error() string {
+	return fmt.Sprintf("firmware update failed with code %s: %s", e.Code, e.Message)
+}
+
+// resultError maps the result codes of the firmware update requests to
+// errors. QTS reports an invalid or foreign image as 1 or 2 and a lack of
+// space as 3. As older firmware versions report these failures with other
+// codes, the message is checked as well.
+func resultError(code, msg string) error {
+	code = strings.TrimSpace(code)
+	msg = filterNullString(msg)
+	if code == "" || code == "0" {
+		return nil
+	}
+
+	lower := strings.ToLower(msg)
+	switch {
+	case code == "1" || code == "2" ||
+		strings.Contains(lower, "incompatible") || strings.Contains(lower, "invalid image"):
+		return &IncompatibleImageError{Code: code, Message: msg}
+	case code == "3" || strings.Contains(lower, "space"):
+		return &InsufficientSpaceError{Code: code, Message: msg}
+	default:
+		return &UpdateError{Code: code, Message: msg}
+	}
+}
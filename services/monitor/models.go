@@ -0,0 +1,150 @@
+package monitor
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/monitor"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+// parseFloat parses numbers like "4.4 %" and returns 0 for values QTS does
+// not report.
+func parseFloat(s string) float64 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(filterNullString(s)), "%"))
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+func parseUint(s string) uint64 {
+	u, err := strconv.ParseUint(strings.TrimSpace(filterNullString(s)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return u
+}
+
+type qdocResourceMonitor struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		Name       string `xml:"name"`
+		OwnContent struct {
+			CPU struct {
+				Usage string `xml:"usage"`
+				Core  []struct {
+					ID    string `xml:"id"`
+					Usage string `xml:"usage"`
+				} `xml:"core"`
+			} `xml:"cpu"`
+			Memory struct {
+				Total     string `xml:"total"`
+				Free      string `xml:"free"`
+				Buffers   string `xml:"buffers"`
+				Cached    string `xml:"cached"`
+				SwapTotal string `xml:"swap_total"`
+				SwapFree  string `xml:"swap_free"`
+			} `xml:"memory"`
+			Network []struct {
+				ID   string `xml:"id"`
+				Name string `xml:"name"`
+				Rx   string `xml:"rx"`
+				Tx   string `xml:"tx"`
+			} `xml:"nic"`
+			Disk []struct {
+				ID        string `xml:"id"`
+				Name      string `xml:"name"`
+				Read      string `xml:"read"`
+				Write     string `xml:"write"`
+				ReadIOPS  string `xml:"read_iops"`
+				WriteIOPS string `xml:"write_iops"`
+			} `xml:"disk"`
+			Process []struct {
+				PID    string `xml:"pid"`
+				Name   string `xml:"name"`
+				User   string `xml:"user"`
+				CPU    string `xml:"cpu"`
+				Memory string `xml:"mem"`
+			} `xml:"process"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+// CPUStats contains the CPU load in percent.
+type CPUStats struct {
+	Usage float64   `xml:"usage" json:"usage" yaml:"usage"`
+	Cores []float64 `xml:"cores" json:"cores" yaml:"cores"`
+}
+
+// MemoryStats contains memory and swap usage in bytes.
+type MemoryStats struct {
+	TotalBytes     uint64 `xml:"totalBytes" json:"totalBytes" yaml:"totalBytes"`
+	UsedBytes      uint64 `xml:"usedBytes" json:"usedBytes" yaml:"usedBytes"`
+	FreeBytes      uint64 `xml:"freeBytes" json:"freeBytes" yaml:"freeBytes"`
+	BuffersBytes   uint64 `xml:"buffersBytes" json:"buffersBytes" yaml:"buffersBytes"`
+	CachedBytes    uint64 `xml:"cachedBytes" json:"cachedBytes" yaml:"cachedBytes"`
+	SwapTotalBytes uint64 `xml:"swapTotalBytes" json:"swapTotalBytes" yaml:"swapTotalBytes"`
+	SwapUsedBytes  uint64 `xml:"swapUsedBytes" json:"swapUsedBytes" yaml:"swapUsedBytes"`
+}
+
+// InterfaceStats contains the throughput of a network interface in bytes per second.
+type InterfaceStats struct {
+	ID            string  `xml:"id" json:"id" yaml:"id"`
+	Name          string  `xml:"name" json:"name" yaml:"name"`
+	RxBytesPerSec float64 `xml:"rxBytesPerSec" json:"rxBytesPerSec" yaml:"rxBytesPerSec"`
+	TxBytesPerSec float64 `xml:"txBytesPerSec" json:"txBytesPerSec" yaml:"txBytesPerSec"`
+}
+
+// DiskIOStats contains the I/O of a disk in bytes and operations per second.
+type DiskIOStats struct {
+	ID               string  `xml:"id" json:"id" yaml:"id"`
+	Name             string  `xml:"name" json:"name" yaml:"name"`
+	ReadBytesPerSec  float64 `xml:"readBytesPerSec" json:"readBytesPerSec" yaml:"readBytesPerSec"`
+	WriteBytesPerSec float64 `xml:"writeBytesPerSec" json:"writeBytesPerSec" yaml:"writeBytesPerSec"`
+	ReadIOPS         float64 `xml:"readIOPS" json:"readIOPS" yaml:"readIOPS"`
+	WriteIOPS        float64 `xml:"writeIOPS" json:"writeIOPS" yaml:"writeIOPS"`
+}
+
+// ProcessStats contains the resource usage of a process.
+type ProcessStats struct {
+	PID         int     `xml:"pid" json:"pid" yaml:"pid"`
+	Name        string  `xml:"name" json:"name" yaml:"name"`
+	User        string  `xml:"user" json:"user" yaml:"user"`
+	CPUUsage    float64 `xml:"cpuUsage" json:"cpuUsage" yaml:"cpuUsage"`
+	MemoryBytes uint64  `xml:"memoryBytes" json:"memoryBytes" yaml:"memoryBytes"`
+}
+
+// Sample is a snapshot of the resource usage of the NAS.
+type Sample struct {
+	Timestamp time.Time        `xml:"timestamp" json:"timestamp" yaml:"timestamp"`
+	CPU       CPUStats         `xml:"cpu" json:"cpu" yaml:"cpu"`
+	Memory    MemoryStats      `xml:"memory" json:"memory" yaml:"memory"`
+	Network   []InterfaceStats `xml:"network" json:"network" yaml:"network"`
+	Disks     []DiskIOStats    `xml:"disks" json:"disks" yaml:"disks"`
+	Processes []ProcessStats   `xml:"processes" json:"processes" yaml:"processes"`
+}
+
+type SnapshotResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Sample            `yaml:",inline"`
+}
+
+// StreamResult is delivered by Client.Stream for every sampling interval.
+// Err is set if the sample could not be retrieved.
+type StreamResult struct {
+	Sample
+	Err error
+}
@@ -0,0 +1,205 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// Snapshot returns the current CPU, memory, network, disk and process usage
+// as reported by the Resource Monitor.
+func (client Client) Snapshot(ctx context.Context) (result SnapshotResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Snapshot")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SnapshotPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "monitor.Client", "Snapshot", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SnapshotSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "monitor.Client", "Snapshot", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.SnapshotResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "monitor.Client", "Snapshot", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SnapshotPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"chart_func":  "resource_monitor",
+		"multicpu":    "1",
+		"include_nic": "1",
+		"disk_select": "all",
+		"proc_list":   "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/management/chartReq.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SnapshotSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SnapshotResponder(resp *http.Response) (result SnapshotResponse, err error) {
+	var doc qdocResourceMonitor
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	content := doc.Func.OwnContent
+	result.Timestamp = time.Now()
+
+	result.CPU.Usage = parseFloat(content.CPU.Usage)
+	result.CPU.Cores = make([]float64, len(content.CPU.Core))
+	for i, core := range content.CPU.Core {
+		result.CPU.Cores[i] = parseFloat(core.Usage)
+	}
+
+	// memory values are reported in KiB
+	mem := content.Memory
+	result.Memory.TotalBytes = parseUint(mem.Total) * 1024
+	result.Memory.FreeBytes = parseUint(mem.Free) * 1024
+	result.Memory.BuffersBytes = parseUint(mem.Buffers) * 1024
+	result.Memory.CachedBytes = parseUint(mem.Cached) * 1024
+	if used := result.Memory.TotalBytes - result.Memory.FreeBytes - result.Memory.BuffersBytes - result.Memory.CachedBytes; used <= result.Memory.TotalBytes {
+		result.Memory.UsedBytes = used
+	}
+	result.Memory.SwapTotalBytes = parseUint(mem.SwapTotal) * 1024
+	if swapFree := parseUint(mem.SwapFree) * 1024; swapFree <= result.Memory.SwapTotalBytes {
+		result.Memory.SwapUsedBytes = result.Memory.SwapTotalBytes - swapFree
+	}
+
+	result.Network = make([]InterfaceStats, len(content.Network))
+	for i, nic := range content.Network {
+		result.Network[i] = InterfaceStats{
+			ID:            nic.ID,
+			Name:          filterNullString(nic.Name),
+			RxBytesPerSec: parseFloat(nic.Rx),
+			TxBytesPerSec: parseFloat(nic.Tx),
+		}
+	}
+
+	result.Disks = make([]DiskIOStats, len(content.Disk))
+	for i, disk := range content.Disk {
+		result.Disks[i] = DiskIOStats{
+			ID:               disk.ID,
+			Name:             filterNullString(disk.Name),
+			ReadBytesPerSec:  parseFloat(disk.Read),
+			WriteBytesPerSec: parseFloat(disk.Write),
+			ReadIOPS:         parseFloat(disk.ReadIOPS),
+			WriteIOPS:        parseFloat(disk.WriteIOPS),
+		}
+	}
+
+	result.Processes = make([]ProcessStats, len(content.Process))
+	for i, proc := range content.Process {
+		pid, _ := strconv.Atoi(proc.PID)
+		result.Processes[i] = ProcessStats{
+			PID:         pid,
+			Name:        filterNullString(proc.Name),
+			User:        filterNullString(proc.User),
+			CPUUsage:    parseFloat(proc.CPU),
+			MemoryBytes: parseUint(proc.Memory) * 1024,
+		}
+	}
+
+	return
+}
+
+// DefaultStreamInterval is the interval used by Stream if interval is not
+// positive.
+const DefaultStreamInterval = 5 * time.Second
+
+// Stream takes a Snapshot every interval and delivers it on the returned
+// channel. The first sample is taken immediately. The channel is closed once
+// ctx is done. Failed samples are delivered with Err set and do not stop the
+// stream. An interval which is not positive is replaced by
+// DefaultStreamInterval.
+func (client Client) Stream(ctx context.Context, interval time.Duration) <-chan StreamResult {
+	if interval <= 0 {
+		interval = DefaultStreamInterval
+	}
+
+	ch := make(chan StreamResult)
+
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			snapshot, err := client.Snapshot(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case ch <- StreamResult{Sample: snapshot.Sample, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
@@ -0,0 +1,16 @@
+package monitorapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/qnap/core-sdk-for-go/services/monitor"
+)
+
+// MonitorClientAPI contains the set of methods on the monitor.Client type.
+type MonitorClientAPI interface {
+	Snapshot(ctx context.Context) (monitor.SnapshotResponse, error)
+	Stream(ctx context.Context, interval time.Duration) <-chan monitor.StreamResult
+}
+
+var _ MonitorClientAPI = (*monitor.Client)(nil)
@@ -0,0 +1,27 @@
+package network
+
+import "github.com/Azure/go-autorest/autorest"
+
+const (
+	// DefaultBaseURI is the default URI used for the service Network
+	DefaultBaseURI = "/cgi-bin"
+)
+
+// BaseClient is the base client for Network.
+type BaseClient struct {
+	autorest.Client
+	BaseURI string
+}
+
+// New creates an instance of the BaseClient client.
+func New() BaseClient {
+	return NewWithBaseURI(DefaultBaseURI)
+}
+
+// NewWithBaseURI creates an instance of the BaseClient client using a custom endpoint.
+func NewWithBaseURI(baseURI string) BaseClient {
+	return BaseClient{
+		Client:  autorest.NewClientWithUserAgent(UserAgent()),
+		BaseURI: baseURI,
+	}
+}
@@ -0,0 +1,237 @@
+package network
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/network"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+func parseInt(s string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(filterNullString(s)))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+type qdocInterfaceList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Interface []struct {
+				Name        string `xml:"name"`
+				MAC         string `xml:"mac"`
+				Link        string `xml:"link"`
+				Speed       string `xml:"speed"`
+				MTU         string `xml:"mtu"`
+				IPv4Mode    string `xml:"ipv4_mode"`
+				IPAddr      string `xml:"ipaddr"`
+				Netmask     string `xml:"netmask"`
+				Gateway     string `xml:"gateway"`
+				IPv6Mode    string `xml:"ipv6_mode"`
+				IPv6Addr    string `xml:"ipv6addr"`
+				IPv6Prefix  string `xml:"ipv6_prefix"`
+				IPv6Gateway string `xml:"ipv6_gateway"`
+				VLANEnable  string `xml:"vlan_enable"`
+				VLANID      string `xml:"vlan_id"`
+				BondMaster  string `xml:"bond_master"`
+			} `xml:"interface"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocDNS struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Auto    string   `xml:"dns_auto"`
+			Servers []string `xml:"dns>server"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocDefaultGateway struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Interface string `xml:"default_gateway"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocBondList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Bond []struct {
+				Name    string   `xml:"name"`
+				Mode    string   `xml:"mode"`
+				Members []string `xml:"members>member"`
+			} `xml:"bond"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocNetOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+// IPv4Mode selects how an interface obtains its IPv4 address.
+type IPv4Mode string
+
+const (
+	IPv4DHCP   IPv4Mode = "dhcp"
+	IPv4Static IPv4Mode = "static"
+)
+
+// IPv6Mode selects how an interface obtains its IPv6 address.
+type IPv6Mode string
+
+const (
+	IPv6Disabled IPv6Mode = "disabled"
+	// IPv6Auto uses stateless address autoconfiguration.
+	IPv6Auto   IPv6Mode = "auto"
+	IPv6DHCP   IPv6Mode = "dhcp"
+	IPv6Static IPv6Mode = "static"
+)
+
+const (
+	// DefaultMTU is the standard Ethernet MTU.
+	DefaultMTU = 1500
+	// JumboMTU is the MTU used for jumbo frames.
+	JumboMTU = 9000
+)
+
+// IPv4Config is the IPv4 configuration of an interface. Address, Netmask and
+// Gateway are only used with IPv4Static.
+type IPv4Config struct {
+	Mode    IPv4Mode `xml:"mode" json:"mode" yaml:"mode"`
+	Address string   `xml:"address" json:"address" yaml:"address"`
+	Netmask string   `xml:"netmask" json:"netmask" yaml:"netmask"`
+	Gateway string   `xml:"gateway" json:"gateway" yaml:"gateway"`
+}
+
+// IPv6Config is the IPv6 configuration of an interface. Address,
+// PrefixLength and Gateway are only used with IPv6Static.
+type IPv6Config struct {
+	Mode         IPv6Mode `xml:"mode" json:"mode" yaml:"mode"`
+	Address      string   `xml:"address" json:"address" yaml:"address"`
+	PrefixLength int      `xml:"prefixLength" json:"prefixLength" yaml:"prefixLength"`
+	Gateway      string   `xml:"gateway" json:"gateway" yaml:"gateway"`
+}
+
+// VLANConfig tags the traffic of an interface with a VLAN ID.
+type VLANConfig struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	ID      int  `xml:"id" json:"id" yaml:"id"`
+}
+
+// InterfaceConfig contains the settings of an interface which can be changed
+// using Client.SetInterface.
+type InterfaceConfig struct {
+	IPv4 IPv4Config `xml:"ipv4" json:"ipv4" yaml:"ipv4"`
+	IPv6 IPv6Config `xml:"ipv6" json:"ipv6" yaml:"ipv6"`
+	// MTU defaults to DefaultMTU. Use JumboMTU to enable jumbo frames.
+	MTU  int        `xml:"mtu" json:"mtu" yaml:"mtu"`
+	VLAN VLANConfig `xml:"vlan" json:"vlan" yaml:"vlan"`
+}
+
+// JumboFrames reports whether the interface uses an MTU larger than
+// DefaultMTU.
+func (c InterfaceConfig) JumboFrames() bool {
+	return c.MTU > DefaultMTU
+}
+
+// Interface is a network interface of the NAS.
+type Interface struct {
+	Name      string `xml:"name" json:"name" yaml:"name"`
+	MAC       string `xml:"mac" json:"mac" yaml:"mac"`
+	Up        bool   `xml:"up" json:"up" yaml:"up"`
+	SpeedMbps int    `xml:"speedMbps" json:"speedMbps" yaml:"speedMbps"`
+	// BondMaster is the name of the bond the interface is a member of.
+	BondMaster      string `xml:"bondMaster" json:"bondMaster" yaml:"bondMaster"`
+	InterfaceConfig `yaml:",inline"`
+}
+
+type InterfacesResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Interfaces        []Interface
+}
+
+// DNSConfig contains the DNS servers used by the NAS.
+type DNSConfig struct {
+	// Auto uses the DNS servers obtained by DHCP.
+	Auto    bool     `xml:"auto" json:"auto" yaml:"auto"`
+	Servers []string `xml:"servers" json:"servers" yaml:"servers"`
+}
+
+type DNSResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	DNSConfig         `yaml:",inline"`
+}
+
+type DefaultGatewayResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// Interface is the name of the interface whose gateway is the default
+	// route.
+	Interface string
+}
+
+// BondMode is the link aggregation mode of a bond.
+type BondMode string
+
+const (
+	BondBalanceRR    BondMode = "balance-rr"
+	BondActiveBackup BondMode = "active-backup"
+	BondBalanceXOR   BondMode = "balance-xor"
+	BondBroadcast    BondMode = "broadcast"
+	// Bond8023AD uses IEEE 802.3ad (LACP) and requires a switch supporting it.
+	Bond8023AD     BondMode = "802.3ad"
+	BondBalanceTLB BondMode = "balance-tlb"
+	BondBalanceALB BondMode = "balance-alb"
+)
+
+// Bond aggregates the links of several interfaces.
+type Bond struct {
+	Name    string   `xml:"name" json:"name" yaml:"name"`
+	Mode    BondMode `xml:"mode" json:"mode" yaml:"mode"`
+	Members []string `xml:"members" json:"members" yaml:"members"`
+}
+
+type BondsResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Bonds             []Bond
+}
+
+// Settings is a snapshot of the network configuration as used by
+// Client.SafeApply to roll back a change.
+type Settings struct {
+	Interfaces     []Interface `xml:"interfaces" json:"interfaces" yaml:"interfaces"`
+	Bonds          []Bond      `xml:"bonds" json:"bonds" yaml:"bonds"`
+	DNS            DNSConfig   `xml:"dns" json:"dns" yaml:"dns"`
+	DefaultGateway string      `xml:"defaultGateway" json:"defaultGateway" yaml:"defaultGateway"`
+}
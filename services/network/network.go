@@ -0,0 +1,748 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// ListInterfaces returns the network interfaces and their configuration.
+func (client Client) ListInterfaces(ctx context.Context) (result InterfacesResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListInterfaces")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListInterfacesPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "ListInterfaces", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListInterfacesSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "network.Client", "ListInterfaces", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListInterfacesResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "ListInterfaces", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListInterfacesPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "net_setting",
+		"func":    "get_interfaces",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListInterfacesSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListInterfacesResponder(resp *http.Response) (result InterfacesResponse, err error) {
+	var doc qdocInterfaceList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Interfaces = make([]Interface, len(doc.Func.OwnContent.Interface))
+	for i, iface := range doc.Func.OwnContent.Interface {
+		result.Interfaces[i] = Interface{
+			Name:       iface.Name,
+			MAC:        strings.ToLower(iface.MAC),
+			Up:         strings.EqualFold(iface.Link, "up"),
+			SpeedMbps:  parseInt(iface.Speed),
+			BondMaster: filterNullString(iface.BondMaster),
+			InterfaceConfig: InterfaceConfig{
+				IPv4: IPv4Config{
+					Mode:    IPv4Mode(iface.IPv4Mode),
+					Address: filterNullString(iface.IPAddr),
+					Netmask: filterNullString(iface.Netmask),
+					Gateway: filterNullString(iface.Gateway),
+				},
+				IPv6: IPv6Config{
+					Mode:         IPv6Mode(iface.IPv6Mode),
+					Address:      filterNullString(iface.IPv6Addr),
+					PrefixLength: parseInt(iface.IPv6Prefix),
+					Gateway:      filterNullString(iface.IPv6Gateway),
+				},
+				MTU: parseInt(iface.MTU),
+				VLAN: VLANConfig{
+					Enabled: iface.VLANEnable == "1",
+					ID:      parseInt(iface.VLANID),
+				},
+			},
+		}
+	}
+
+	return
+}
+
+// SetInterface replaces the configuration of the interface name. Changing
+// the address the client connects to may drop the connection before the
+// response is received; use SafeApply to verify such changes.
+func (client Client) SetInterface(ctx context.Context, name string, cfg InterfaceConfig) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetInterface")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetInterfacePreparer(ctx, name, cfg)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetInterface", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetInterfaceSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetInterface", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetInterfaceResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetInterface", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetInterfacePreparer(ctx context.Context, name string, cfg InterfaceConfig) (*http.Request, error) {
+	mtu := cfg.MTU
+	if mtu == 0 {
+		mtu = DefaultMTU
+	}
+	ipv4Mode := cfg.IPv4.Mode
+	if ipv4Mode == "" {
+		ipv4Mode = IPv4DHCP
+	}
+	ipv6Mode := cfg.IPv6.Mode
+	if ipv6Mode == "" {
+		ipv6Mode = IPv6Disabled
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":      []string{"net_setting"},
+			"func":         []string{"set_interface"},
+			"name":         []string{name},
+			"ipv4_mode":    []string{string(ipv4Mode)},
+			"ipaddr":       []string{cfg.IPv4.Address},
+			"netmask":      []string{cfg.IPv4.Netmask},
+			"gateway":      []string{cfg.IPv4.Gateway},
+			"ipv6_mode":    []string{string(ipv6Mode)},
+			"ipv6addr":     []string{cfg.IPv6.Address},
+			"ipv6_prefix":  []string{strconv.Itoa(cfg.IPv6.PrefixLength)},
+			"ipv6_gateway": []string{cfg.IPv6.Gateway},
+			"mtu":          []string{strconv.Itoa(mtu)},
+			"vlan_enable":  []string{boolString(cfg.VLAN.Enabled)},
+			"vlan_id":      []string{strconv.Itoa(cfg.VLAN.ID)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetInterfaceSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetInterfaceResponder(resp *http.Response) (err error) {
+	var doc qdocNetOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetDNS returns the DNS servers used by the NAS.
+func (client Client) GetDNS(ctx context.Context) (result DNSResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetDNS")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetDNSPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "GetDNS", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetDNSSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "network.Client", "GetDNS", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetDNSResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "GetDNS", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetDNSPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "net_setting",
+		"func":    "get_dns",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetDNSSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetDNSResponder(resp *http.Response) (result DNSResponse, err error) {
+	var doc qdocDNS
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Auto = doc.Func.OwnContent.Auto == "1"
+	for _, server := range doc.Func.OwnContent.Servers {
+		if server = filterNullString(server); server != "" {
+			result.Servers = append(result.Servers, server)
+		}
+	}
+
+	return
+}
+
+// SetDNS replaces the DNS servers used by the NAS.
+func (client Client) SetDNS(ctx context.Context, cfg DNSConfig) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetDNS")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetDNSPreparer(ctx, cfg)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetDNS", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetDNSSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetDNS", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetDNSResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetDNS", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetDNSPreparer(ctx context.Context, cfg DNSConfig) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":  []string{"net_setting"},
+			"func":     []string{"set_dns"},
+			"dns_auto": []string{boolString(cfg.Auto)},
+			"dns":      []string{strings.Join(cfg.Servers, ",")},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetDNSSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetDNSResponder(resp *http.Response) (err error) {
+	var doc qdocNetOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetDefaultGateway returns the interface whose gateway is the default route.
+func (client Client) GetDefaultGateway(ctx context.Context) (result DefaultGatewayResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetDefaultGateway")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetDefaultGatewayPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "GetDefaultGateway", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetDefaultGatewaySender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "network.Client", "GetDefaultGateway", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetDefaultGatewayResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "GetDefaultGateway", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetDefaultGatewayPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "net_setting",
+		"func":    "get_default_gateway",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetDefaultGatewaySender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetDefaultGatewayResponder(resp *http.Response) (result DefaultGatewayResponse, err error) {
+	var doc qdocDefaultGateway
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Interface = filterNullString(doc.Func.OwnContent.Interface)
+
+	return
+}
+
+// SetDefaultGateway uses the gateway of the interface name as default route.
+func (client Client) SetDefaultGateway(ctx context.Context, name string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetDefaultGateway")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetDefaultGatewayPreparer(ctx, name)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetDefaultGateway", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetDefaultGatewaySender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetDefaultGateway", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetDefaultGatewayResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "SetDefaultGateway", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetDefaultGatewayPreparer(ctx context.Context, name string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":         []string{"net_setting"},
+			"func":            []string{"set_default_gateway"},
+			"default_gateway": []string{name},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetDefaultGatewaySender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetDefaultGatewayResponder(resp *http.Response) (err error) {
+	var doc qdocNetOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// ListBonds returns the link aggregation groups.
+func (client Client) ListBonds(ctx context.Context) (result BondsResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListBonds")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListBondsPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "ListBonds", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListBondsSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "network.Client", "ListBonds", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListBondsResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "ListBonds", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListBondsPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "net_setting",
+		"func":    "get_bonds",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListBondsSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListBondsResponder(resp *http.Response) (result BondsResponse, err error) {
+	var doc qdocBondList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Bonds = make([]Bond, len(doc.Func.OwnContent.Bond))
+	for i, bond := range doc.Func.OwnContent.Bond {
+		result.Bonds[i] = Bond{
+			Name:    bond.Name,
+			Mode:    BondMode(bond.Mode),
+			Members: append([]string(nil), bond.Members...),
+		}
+	}
+
+	return
+}
+
+// CreateBond aggregates the interfaces bond.Members. The configuration of the
+// first member is moved to the bond.
+func (client Client) CreateBond(ctx context.Context, bond Bond) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.CreateBond")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CreateBondPreparer(ctx, bond)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "CreateBond", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateBondSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "CreateBond", resp, "Failure sending request")
+		return
+	}
+
+	err = client.CreateBondResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "CreateBond", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CreateBondPreparer(ctx context.Context, bond Bond) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"net_setting"},
+			"func":    []string{"add_bond"},
+			"name":    []string{bond.Name},
+			"mode":    []string{string(bond.Mode)},
+			"members": []string{strings.Join(bond.Members, ",")},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) CreateBondSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) CreateBondResponder(resp *http.Response) (err error) {
+	var doc qdocNetOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// DeleteBond dissolves the bond name.
+func (client Client) DeleteBond(ctx context.Context, name string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.DeleteBond")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DeleteBondPreparer(ctx, name)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "DeleteBond", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteBondSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "DeleteBond", resp, "Failure sending request")
+		return
+	}
+
+	err = client.DeleteBondResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "network.Client", "DeleteBond", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) DeleteBondPreparer(ctx context.Context, name string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"net_setting"},
+			"func":    []string{"del_bond"},
+			"name":    []string{name},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) DeleteBondSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) DeleteBondResponder(resp *http.Response) (err error) {
+	var doc qdocNetOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,25 @@
+package networkapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/network"
+)
+
+// NetworkClientAPI contains the set of methods on the network.Client type.
+type NetworkClientAPI interface {
+	ListInterfaces(ctx context.Context) (network.InterfacesResponse, error)
+	SetInterface(ctx context.Context, name string, cfg network.InterfaceConfig) error
+	GetDNS(ctx context.Context) (network.DNSResponse, error)
+	SetDNS(ctx context.Context, cfg network.DNSConfig) error
+	GetDefaultGateway(ctx context.Context) (network.DefaultGatewayResponse, error)
+	SetDefaultGateway(ctx context.Context, name string) error
+	ListBonds(ctx context.Context) (network.BondsResponse, error)
+	CreateBond(ctx context.Context, bond network.Bond) error
+	DeleteBond(ctx context.Context, name string) error
+	GetSettings(ctx context.Context) (network.Settings, error)
+	Restore(ctx context.Context, settings network.Settings) error
+	SafeApply(ctx context.Context, opts network.SafeApplyOptions, change func(ctx context.Context, client network.Client) error) error
+}
+
+var _ NetworkClientAPI = (*network.Client)(nil)
@@ -0,0 +1,270 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+const (
+	// DefaultVerifyTimeout is the time SafeApply waits for the NAS to become
+	// reachable if SafeApplyOptions.Timeout is zero.
+	DefaultVerifyTimeout = 2 * time.Minute
+	// DefaultSettleTime is the time the NAS has to stay reachable after a
+	// change if SafeApplyOptions.SettleTime is zero.
+	DefaultSettleTime = 30 * time.Second
+	// rollbackTimeout limits restoring the previous settings.
+	rollbackTimeout = 2 * time.Minute
+	// pollInterval is the time between two reachability checks.
+	pollInterval = 5 * time.Second
+)
+
+// SafeApplyOptions controls Client.SafeApply.
+type SafeApplyOptions struct {
+	// BaseURI is the endpoint the NAS is expected to be reachable at after
+	// the change, e.g. if its address changes. Defaults to the BaseURI of
+	// the client.
+	BaseURI string
+	// Timeout is the time the NAS may take to become reachable. Defaults to
+	// DefaultVerifyTimeout.
+	Timeout time.Duration
+	// SettleTime is the time the NAS has to stay reachable before the change
+	// is accepted, as QTS applies some changes asynchronously after the
+	// request returns. Every check within SettleTime has to succeed, so
+	// verification takes at least SettleTime and at most Timeout plus
+	// SettleTime. Defaults to DefaultSettleTime.
+	SettleTime time.Duration
+	// Probe is an additional check that has to succeed, e.g. reaching a
+	// service through the NAS.
+	Probe func(ctx context.Context) error
+}
+
+// RollbackError is returned by Client.SafeApply if the change has been
+// rolled back.
+type RollbackError struct {
+	// Err is the reason for the rollback.
+	Err error
+	// RollbackErr is set if restoring the previous settings failed as well.
+	RollbackErr error
+}
+
+func (e *RollbackError) Error() string {
+	if e.RollbackErr != nil {
+		return fmt.Sprintf("network change failed: %v; rollback failed: %v", e.Err, e.RollbackErr)
+	}
+	return fmt.Sprintf("network change rolled back: %v", e.Err)
+}
+
+func (e *RollbackError) Unwrap() error {
+	return e.Err
+}
+
+// GetSettings returns a snapshot of the network configuration.
+func (client Client) GetSettings(ctx context.Context) (settings Settings, err error) {
+	ifaces, err := client.ListInterfaces(ctx)
+	if err != nil {
+		return
+	}
+	bonds, err := client.ListBonds(ctx)
+	if err != nil {
+		return
+	}
+	dns, err := client.GetDNS(ctx)
+	if err != nil {
+		return
+	}
+	gw, err := client.GetDefaultGateway(ctx)
+	if err != nil {
+		return
+	}
+
+	settings.Interfaces = ifaces.Interfaces
+	settings.Bonds = bonds.Bonds
+	settings.DNS = dns.DNSConfig
+	settings.DefaultGateway = gw.Interface
+	return
+}
+
+// Restore applies the snapshot settings, changing only what differs from the
+// current configuration.
+func (client Client) Restore(ctx context.Context, settings Settings) error {
+	current, err := client.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	bonds := map[string]Bond{}
+	for _, b := range settings.Bonds {
+		bonds[b.Name] = b
+	}
+	for _, b := range current.Bonds {
+		if want, ok := bonds[b.Name]; !ok || !bondEqual(want, b) {
+			if err = client.DeleteBond(ctx, b.Name); err != nil {
+				return err
+			}
+		}
+	}
+	existing := map[string]Bond{}
+	for _, b := range current.Bonds {
+		existing[b.Name] = b
+	}
+	for _, b := range settings.Bonds {
+		if have, ok := existing[b.Name]; !ok || !bondEqual(have, b) {
+			if err = client.CreateBond(ctx, b); err != nil {
+				return err
+			}
+		}
+	}
+
+	ifaces := map[string]InterfaceConfig{}
+	for _, iface := range current.Interfaces {
+		ifaces[iface.Name] = iface.InterfaceConfig
+	}
+	for _, iface := range settings.Interfaces {
+		if iface.BondMaster != "" {
+			continue
+		}
+		if have, ok := ifaces[iface.Name]; ok && reflect.DeepEqual(have, iface.InterfaceConfig) {
+			continue
+		}
+		if err = client.SetInterface(ctx, iface.Name, iface.InterfaceConfig); err != nil {
+			return err
+		}
+	}
+
+	if !reflect.DeepEqual(current.DNS, settings.DNS) {
+		if err = client.SetDNS(ctx, settings.DNS); err != nil {
+			return err
+		}
+	}
+	if settings.DefaultGateway != "" && current.DefaultGateway != settings.DefaultGateway {
+		if err = client.SetDefaultGateway(ctx, settings.DefaultGateway); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bondEqual reports whether a and b have the same settings, ignoring the
+// order of the members.
+func bondEqual(a, b Bond) bool {
+	a.Members = sortedStrings(a.Members)
+	b.Members = sortedStrings(b.Members)
+	return reflect.DeepEqual(a, b)
+}
+
+func sortedStrings(s []string) []string {
+	sorted := append([]string{}, s...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// SafeApply takes a snapshot of the network configuration, calls change and
+// verifies that the NAS becomes reachable within opts.Timeout and stays
+// reachable for opts.SettleTime. If change or the verification fails, the
+// snapshot is restored and a *RollbackError is returned.
+//
+// The rollback is also attempted if ctx is done before the change has been
+// verified. It uses a context detached from ctx which keeps its values but
+// is limited to its own timeout, so a short caller deadline does not leave
+// the NAS with an unverified configuration.
+//
+// The rollback is sent to the original endpoint of the client, so it only
+// succeeds if the NAS is still reachable there, e.g. when a second interface
+// is reconfigured or the new address does not work but the old one does.
+func (client Client) SafeApply(ctx context.Context, opts SafeApplyOptions, change func(ctx context.Context, client Client) error) error {
+	settings, err := client.GetSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = change(ctx, client)
+	if err == nil {
+		err = client.verify(ctx, opts)
+	}
+	if err == nil {
+		return nil
+	}
+
+	rollbackCtx, cancel := context.WithTimeout(detachedContext{ctx}, rollbackTimeout)
+	defer cancel()
+	return &RollbackError{Err: err, RollbackErr: client.Restore(rollbackCtx, settings)}
+}
+
+// detachedContext keeps the values of a context but not its deadline and
+// cancellation.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// verify polls the NAS until it responds and opts.Probe succeeds, then keeps
+// polling until every check within opts.SettleTime has succeeded.
+func (client Client) verify(ctx context.Context, opts SafeApplyOptions) error {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = DefaultVerifyTimeout
+	}
+	settle := opts.SettleTime
+	if settle == 0 {
+		settle = DefaultSettleTime
+	}
+	baseURI := opts.BaseURI
+	if baseURI == "" {
+		baseURI = client.BaseURI
+	}
+	probe := client.probeClient(baseURI)
+
+	deadline := time.Now().Add(timeout)
+	verifyCtx, cancel := context.WithDeadline(ctx, deadline.Add(settle+pollInterval))
+	defer cancel()
+
+	// reachableSince is the time of the first check of the current run of
+	// successful checks.
+	var reachableSince time.Time
+	for {
+		now := time.Now()
+		_, err := probe.ListInterfaces(verifyCtx)
+		if err == nil && opts.Probe != nil {
+			err = opts.Probe(verifyCtx)
+		}
+		if err != nil && !reachableSince.IsZero() {
+			err = fmt.Errorf("NAS at %s became unreachable after %v: %w", baseURI, now.Sub(reachableSince), err)
+			reachableSince = time.Time{}
+		} else if err == nil && reachableSince.IsZero() {
+			reachableSince = now
+		}
+		if !reachableSince.IsZero() && now.Sub(reachableSince) >= settle {
+			return nil
+		}
+		if reachableSince.IsZero() && now.After(deadline) {
+			return fmt.Errorf("NAS not reachable at %s after %v: %w", baseURI, timeout, err)
+		}
+
+		t := time.NewTimer(pollInterval)
+		select {
+		case <-t.C:
+		case <-verifyCtx.Done():
+			t.Stop()
+			if err == nil {
+				err = verifyCtx.Err()
+			}
+			return fmt.Errorf("NAS not verified at %s: %w", baseURI, err)
+		}
+	}
+}
+
+// probeClient returns a client sharing the HTTP settings of client which
+// does not retry failed requests.
+func (client Client) probeClient(baseURI string) Client {
+	probe := NewClientWithBaseURI(baseURI)
+	probe.Client = client.Client
+	probe.RetryAttempts = 1
+	return probe
+}
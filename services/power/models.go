@@ -0,0 +1,136 @@
+package power
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/power"
+
+type qdocPowerOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+type qdocPowerSchedule struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		Name       string `xml:"name"`
+		OwnContent struct {
+			Enable   string `xml:"enable"`
+			Schedule []struct {
+				Action string `xml:"action"`
+				Day    string `xml:"day"`
+				Hour   int    `xml:"hour"`
+				Minute int    `xml:"minute"`
+			} `xml:"schedule"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+// ScheduleAction is the action performed by a PowerScheduleEntry.
+type ScheduleAction string
+
+const (
+	ScheduleActionPowerOn  ScheduleAction = "on"
+	ScheduleActionPowerOff ScheduleAction = "off"
+	ScheduleActionRestart  ScheduleAction = "restart"
+	ScheduleActionSleep    ScheduleAction = "sleep"
+)
+
+// ScheduleDay is the day or the range of days a PowerScheduleEntry applies to.
+type ScheduleDay string
+
+const (
+	ScheduleDayEveryday  ScheduleDay = "everyday"
+	ScheduleDayWeekdays  ScheduleDay = "weekdays"
+	ScheduleDayWeekends  ScheduleDay = "weekends"
+	ScheduleDayMonday    ScheduleDay = "mon"
+	ScheduleDayTuesday   ScheduleDay = "tue"
+	ScheduleDayWednesday ScheduleDay = "wed"
+	ScheduleDayThursday  ScheduleDay = "thu"
+	ScheduleDayFriday    ScheduleDay = "fri"
+	ScheduleDaySaturday  ScheduleDay = "sat"
+	ScheduleDaySunday    ScheduleDay = "sun"
+)
+
+// PowerScheduleEntry turns the NAS on or off at the given time of day.
+type PowerScheduleEntry struct {
+	Action ScheduleAction `xml:"action" json:"action" yaml:"action"`
+	Day    ScheduleDay    `xml:"day" json:"day" yaml:"day"`
+	Hour   int            `xml:"hour" json:"hour" yaml:"hour"`
+	Minute int            `xml:"minute" json:"minute" yaml:"minute"`
+}
+
+// PowerSchedule contains the power on/off schedule of the NAS.
+type PowerSchedule struct {
+	Enabled bool                 `xml:"enabled" json:"enabled" yaml:"enabled"`
+	Entries []PowerScheduleEntry `xml:"entries" json:"entries" yaml:"entries"`
+}
+
+type ScheduleResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	PowerSchedule     `yaml:",inline"`
+}
+
+type qdocSysInfo struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		Name       string `xml:"name"`
+		OwnContent struct {
+			Root struct {
+				// the interfaces are reported as numbered elements like
+				// ifname1, eth_mac1, ifname2, eth_mac2, ...
+				Elements []struct {
+					XMLName xml.Name
+					Value   string `xml:",chardata"`
+				} `xml:",any"`
+			} `xml:"root"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+// InterfaceMAC is the MAC address of a network interface of the NAS.
+type InterfaceMAC struct {
+	Name string           `xml:"name" json:"name" yaml:"name"`
+	MAC  net.HardwareAddr `xml:"mac" json:"-" yaml:"mac"`
+}
+
+// MarshalJSON encodes the MAC address in its textual form.
+func (i InterfaceMAC) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name string `json:"name"`
+		MAC  string `json:"mac"`
+	}{i.Name, i.MAC.String()})
+}
+
+// UnmarshalJSON decodes the textual form of the MAC address.
+func (i *InterfaceMAC) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Name string `json:"name"`
+		MAC  string `json:"mac"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	mac, err := net.ParseMAC(v.MAC)
+	if err != nil {
+		return err
+	}
+
+	i.Name = v.Name
+	i.MAC = mac
+	return nil
+}
+
+type MACAddressesResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Interfaces        []InterfaceMAC
+}
@@ -0,0 +1,556 @@
+package power
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+	"github.com/qnap/core-sdk-for-go/services/auth"
+)
+
+const (
+	// pollInterval is the time between two boot status requests while
+	// waiting for a reboot.
+	pollInterval = 10 * time.Second
+	// rebootTimeout is the maximum time to wait for the NAS to go down and
+	// to come back after a reboot.
+	rebootTimeout = 15 * time.Minute
+	// downFailures is the number of consecutive failed boot status requests
+	// after which WaitForReboot considers the NAS down.
+	downFailures = 3
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// Reboot restarts the NAS. Unless dontWait is set, Reboot waits until the
+// NAS went down and has finished booting again.
+func (client Client) Reboot(ctx context.Context, dontWait bool) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Reboot")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	var before auth.ShutdownInfo
+	if !dontWait {
+		// The NAS may report an earlier shutdown, so only a newer one
+		// means the reboot has started.
+		if before, err = client.LastShutdown(ctx); err != nil {
+			return
+		}
+	}
+
+	req, err := client.RebootPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "Reboot", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.RebootSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "Reboot", resp, "Failure sending request")
+		return
+	}
+
+	err = client.RebootResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "Reboot", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait {
+		err = client.WaitForReboot(ctx, before)
+	}
+
+	return
+}
+
+func (client Client) RebootPreparer(ctx context.Context) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"power_mgmt"},
+			"apply":   []string{"restart"},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// RebootSender sends the request without retries, as the NAS may already be
+// going down when the response is lost.
+func (client Client) RebootSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) RebootResponder(resp *http.Response) (err error) {
+	var doc qdocPowerOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// Shutdown powers off the NAS.
+func (client Client) Shutdown(ctx context.Context) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Shutdown")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ShutdownPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "Shutdown", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ShutdownSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "Shutdown", resp, "Failure sending request")
+		return
+	}
+
+	err = client.ShutdownResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "Shutdown", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ShutdownPreparer(ctx context.Context) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"power_mgmt"},
+			"apply":   []string{"shutdown"},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// ShutdownSender sends the request without retries for the same reason as
+// RebootSender.
+func (client Client) ShutdownSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) ShutdownResponder(resp *http.Response) (err error) {
+	var doc qdocPowerOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// GetSchedule returns the power on/off schedule of the NAS.
+func (client Client) GetSchedule(ctx context.Context) (result ScheduleResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSchedule")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSchedulePreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "GetSchedule", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetScheduleSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "power.Client", "GetSchedule", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetScheduleResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "GetSchedule", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSchedulePreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "power_mgmt",
+		"apply":   "get_schedule",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetScheduleSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetScheduleResponder(resp *http.Response) (result ScheduleResponse, err error) {
+	var doc qdocPowerSchedule
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Enabled = doc.Func.OwnContent.Enable == "1"
+	result.Entries = make([]PowerScheduleEntry, len(doc.Func.OwnContent.Schedule))
+	for i, item := range doc.Func.OwnContent.Schedule {
+		result.Entries[i] = PowerScheduleEntry{
+			Action: ScheduleAction(item.Action),
+			Day:    ScheduleDay(item.Day),
+			Hour:   item.Hour,
+			Minute: item.Minute,
+		}
+	}
+
+	return
+}
+
+// SetSchedule replaces the power on/off schedule of the NAS.
+func (client Client) SetSchedule(ctx context.Context, schedule PowerSchedule) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetSchedule")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetSchedulePreparer(ctx, schedule)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "SetSchedule", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetScheduleSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "SetSchedule", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetScheduleResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "SetSchedule", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetSchedulePreparer(ctx context.Context, schedule PowerSchedule) (*http.Request, error) {
+	enable := "0"
+	if schedule.Enabled {
+		enable = "1"
+	}
+
+	data := url.Values{
+		"subfunc": []string{"power_mgmt"},
+		"apply":   []string{"set_schedule"},
+		"enable":  []string{enable},
+		"count":   []string{strconv.Itoa(len(schedule.Entries))},
+	}
+	for i, entry := range schedule.Entries {
+		n := strconv.Itoa(i)
+		data.Set("action"+n, string(entry.Action))
+		data.Set("day"+n, string(entry.Day))
+		data.Set("hour"+n, strconv.Itoa(entry.Hour))
+		data.Set("minute"+n, strconv.Itoa(entry.Minute))
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// SetScheduleSender sends the request without retries, as a schedule taking
+// effect immediately may already have shut the NAS down.
+func (client Client) SetScheduleSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) SetScheduleResponder(resp *http.Response) (err error) {
+	var doc qdocPowerOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	return
+}
+
+// GetMACAddresses returns the MAC addresses of the network interfaces of the
+// NAS. They can be used to wake the NAS with WakeOnLAN once it is powered off.
+func (client Client) GetMACAddresses(ctx context.Context) (result MACAddressesResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetMACAddresses")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetMACAddressesPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "GetMACAddresses", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetMACAddressesSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "power.Client", "GetMACAddresses", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetMACAddressesResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "power.Client", "GetMACAddresses", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetMACAddressesPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc":  "sysinfo",
+		"hd":       "no",
+		"multicpu": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/management/manaRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetMACAddressesSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetMACAddressesResponder(resp *http.Response) (result MACAddressesResponse, err error) {
+	var doc qdocSysInfo
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	values := map[string]string{}
+	for _, elem := range doc.Func.OwnContent.Root.Elements {
+		values[elem.XMLName.Local] = strings.TrimSpace(elem.Value)
+	}
+
+	count, _ := strconv.Atoi(values["eth_count"])
+	for i := 1; i <= count; i++ {
+		n := strconv.Itoa(i)
+		mac, perr := net.ParseMAC(values["eth_mac"+n])
+		if perr != nil {
+			continue
+		}
+
+		name := values["ifname"+n]
+		if name == "" {
+			name = "eth" + strconv.Itoa(i-1)
+		}
+		result.Interfaces = append(result.Interfaces, InterfaceMAC{Name: name, MAC: mac})
+	}
+
+	return
+}
+
+// WaitUntilBack polls the unauthenticated login page until the NAS has
+// finished booting.
+func (client Client) WaitUntilBack(ctx context.Context) error {
+	status := client.bootStatusClient()
+
+	deadline := time.Now().Add(rebootTimeout)
+	for time.Now().Before(deadline) {
+		stat, err := status.GetBootStatus(ctx)
+		if err == nil && !stat.IsBooting {
+			return nil
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("failed to wait for the NAS to finish booting")
+}
+
+// LastShutdown returns the last or pending shutdown reported by the NAS.
+// Call it before requesting a reboot and pass the result to WaitForReboot.
+func (client Client) LastShutdown(ctx context.Context) (auth.ShutdownInfo, error) {
+	stat, err := client.bootStatusClient().GetBootStatus(ctx)
+	if err != nil {
+		return auth.ShutdownInfo{}, err
+	}
+	return stat.ShutdownInfo, nil
+}
+
+// WaitForReboot waits until the NAS went down and then waits until it has
+// finished booting again. The NAS is considered down once it reports that it
+// is booting, reports a shutdown newer than before, as returned by
+// LastShutdown prior to the reboot, or fails downFailures consecutive boot
+// status requests. A single failed request, e.g. a transient network error
+// before the reboot has started, is not enough. If before is zero, the
+// shutdown is not compared.
+func (client Client) WaitForReboot(ctx context.Context, before auth.ShutdownInfo) error {
+	status := client.bootStatusClient()
+
+	down := false
+	failures := 0
+	deadline := time.Now().Add(rebootTimeout)
+	for !down && time.Now().Before(deadline) {
+		stat, err := status.GetBootStatus(ctx)
+		if err != nil {
+			failures++
+		} else {
+			failures = 0
+		}
+		if failures >= downFailures || (err == nil && (stat.IsBooting || newerShutdown(stat.ShutdownInfo, before))) {
+			down = true
+			break
+		}
+
+		if err := sleep(ctx, pollInterval); err != nil {
+			return err
+		}
+	}
+
+	if !down {
+		return fmt.Errorf("failed to wait for the NAS going down")
+	}
+
+	return client.WaitUntilBack(ctx)
+}
+
+// newerShutdown reports whether info describes a shutdown after before.
+// Numeric timestamps are compared as such, others only for equality.
+func newerShutdown(info, before auth.ShutdownInfo) bool {
+	if before.Timestamp == "" || info.Timestamp == "" {
+		return false
+	}
+
+	t, err := strconv.ParseInt(strings.TrimSpace(info.Timestamp), 10, 64)
+	b, berr := strconv.ParseInt(strings.TrimSpace(before.Timestamp), 10, 64)
+	if err == nil && berr == nil {
+		return t > b
+	}
+	return info.Timestamp != before.Timestamp
+}
+
+// bootStatusClient returns an auth.Client sharing the HTTP settings of the
+// client. Each boot status request is sent only once, so an unreachable NAS
+// does not delay polling.
+func (client Client) bootStatusClient() auth.Client {
+	status := auth.NewClientWithBaseURI(client.BaseURI)
+	status.Client = client.Client
+	status.RetryAttempts = 1
+	return status
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,22 @@
+package powerapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/auth"
+	"github.com/qnap/core-sdk-for-go/services/power"
+)
+
+// PowerClientAPI contains the set of methods on the power.Client type.
+type PowerClientAPI interface {
+	Reboot(ctx context.Context, dontWait bool) error
+	Shutdown(ctx context.Context) error
+	LastShutdown(ctx context.Context) (auth.ShutdownInfo, error)
+	WaitForReboot(ctx context.Context, before auth.ShutdownInfo) error
+	WaitUntilBack(ctx context.Context) error
+	GetSchedule(ctx context.Context) (power.ScheduleResponse, error)
+	SetSchedule(ctx context.Context, schedule power.PowerSchedule) error
+	GetMACAddresses(ctx context.Context) (power.MACAddressesResponse, error)
+}
+
+var _ PowerClientAPI = (*power.Client)(nil)
@@ -0,0 +1,157 @@
+package power
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// DefaultWakeAddr is the UDP address magic packets are sent to if
+// WakeOptions.Addr is empty.
+const DefaultWakeAddr = "255.255.255.255:9"
+
+// WakeOptions controls how a Wake-on-LAN magic packet is sent.
+type WakeOptions struct {
+	// Addr is the UDP address the packet is sent to. It can be a broadcast
+	// address like 192.168.1.255:9 or the unicast address of the NAS if the
+	// switch still knows its port. Defaults to DefaultWakeAddr.
+	Addr string
+	// Password is the optional SecureOn password. It must be 4 or 6 bytes long.
+	Password []byte
+}
+
+// MagicPacket builds the Wake-on-LAN magic packet for mac. The SecureOn
+// password is appended if given.
+func MagicPacket(mac net.HardwareAddr, password []byte) ([]byte, error) {
+	if len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q: Wake-on-LAN requires a 48 bit address", mac.String())
+	}
+	if len(password) != 0 && len(password) != 4 && len(password) != 6 {
+		return nil, fmt.Errorf("invalid SecureOn password: must be 4 or 6 bytes long")
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.Repeat([]byte{0xff}, 6))
+	for i := 0; i < 16; i++ {
+		buf.Write(mac)
+	}
+	buf.Write(password)
+
+	return buf.Bytes(), nil
+}
+
+// WakeOnLAN sends a Wake-on-LAN magic packet for mac.
+func WakeOnLAN(ctx context.Context, mac net.HardwareAddr, opts WakeOptions) error {
+	packet, err := MagicPacket(mac, opts.Password)
+	if err != nil {
+		return err
+	}
+
+	addr := opts.Addr
+	if addr == "" {
+		addr = DefaultWakeAddr
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	_, err = conn.Write(packet)
+	return err
+}
+
+// MACCache remembers the MAC addresses of NAS units while they are online so
+// that they can be woken up after they have been powered off. It is safe for
+// concurrent use.
+type MACCache struct {
+	mu      sync.RWMutex
+	entries map[string][]InterfaceMAC
+}
+
+// NewMACCache creates an empty MACCache.
+func NewMACCache() *MACCache {
+	return &MACCache{entries: map[string][]InterfaceMAC{}}
+}
+
+// Refresh fetches the MAC addresses of the NAS behind client and stores them
+// under key, e.g. the host name of the NAS.
+func (c *MACCache) Refresh(ctx context.Context, key string, client Client) error {
+	result, err := client.GetMACAddresses(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.Set(key, result.Interfaces)
+	return nil
+}
+
+// Set stores the MAC addresses for key.
+func (c *MACCache) Set(key string, interfaces []InterfaceMAC) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = map[string][]InterfaceMAC{}
+	}
+	c.entries[key] = append([]InterfaceMAC(nil), interfaces...)
+}
+
+// Get returns the MAC addresses stored for key.
+func (c *MACCache) Get(key string) ([]InterfaceMAC, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	interfaces, ok := c.entries[key]
+	return append([]InterfaceMAC(nil), interfaces...), ok
+}
+
+// Wake sends a magic packet to every MAC address stored for key.
+func (c *MACCache) Wake(ctx context.Context, key string, opts WakeOptions) error {
+	interfaces, ok := c.Get(key)
+	if !ok || len(interfaces) == 0 {
+		return fmt.Errorf("no MAC address known for %s", key)
+	}
+
+	for _, iface := range interfaces {
+		if err := WakeOnLAN(ctx, iface.MAC, opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Save writes the cache as JSON to w.
+func (c *MACCache) Save(w io.Writer) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return json.NewEncoder(w).Encode(c.entries)
+}
+
+// Load replaces the content of the cache with the JSON read from r.
+func (c *MACCache) Load(r io.Reader) error {
+	entries := map[string][]InterfaceMAC{}
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = entries
+	return nil
+}
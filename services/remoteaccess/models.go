@@ -0,0 +1,227 @@
+package remoteaccess
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/remoteaccess"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+func parseInt(s string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(filterNullString(s)))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+type qdocService struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Enable string `xml:"enable"`
+			Port   string `xml:"port"`
+			SFTP   string `xml:"sftp_enable"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocSNMP struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Enable      string   `xml:"enable"`
+			Port        string   `xml:"port"`
+			Versions    []string `xml:"versions>version"`
+			Communities []struct {
+				Name   string `xml:"name"`
+				Access string `xml:"access"`
+			} `xml:"communities>community"`
+			Users []struct {
+				Name          string `xml:"name"`
+				SecurityLevel string `xml:"security_level"`
+				AuthProtocol  string `xml:"auth_protocol"`
+				PrivProtocol  string `xml:"priv_protocol"`
+			} `xml:"users>user"`
+			Traps []struct {
+				Address   string `xml:"address"`
+				Port      string `xml:"port"`
+				Version   string `xml:"version"`
+				Community string `xml:"community"`
+				User      string `xml:"user"`
+			} `xml:"traps>trap"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocServiceOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+const (
+	// DefaultSSHPort is the port used by SetSSH if SSHSettings.Port is zero.
+	DefaultSSHPort = 22
+	// DefaultTelnetPort is the port used by SetTelnet if
+	// TelnetSettings.Port is zero.
+	DefaultTelnetPort = 13131
+	// DefaultSNMPPort is the port used by SetSNMP if SNMPSettings.Port is
+	// zero.
+	DefaultSNMPPort = 161
+)
+
+// SSHSettings are the settings of the SSH service.
+type SSHSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	// Port defaults to DefaultSSHPort.
+	Port int `xml:"port" json:"port" yaml:"port"`
+	// SFTP enables the SFTP subsystem.
+	SFTP bool `xml:"sftp" json:"sftp" yaml:"sftp"`
+}
+
+type SSHResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	SSHSettings       `yaml:",inline"`
+}
+
+// TelnetSettings are the settings of the Telnet service.
+type TelnetSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	// Port defaults to DefaultTelnetPort.
+	Port int `xml:"port" json:"port" yaml:"port"`
+}
+
+type TelnetResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	TelnetSettings    `yaml:",inline"`
+}
+
+// SNMPVersion is a version of the SNMP protocol.
+type SNMPVersion string
+
+const (
+	SNMPv1  SNMPVersion = "v1"
+	SNMPv2c SNMPVersion = "v2c"
+	SNMPv3  SNMPVersion = "v3"
+)
+
+// SNMPAccess is the access granted to an SNMPv1/v2c community.
+type SNMPAccess string
+
+const (
+	SNMPReadOnly  SNMPAccess = "ro"
+	SNMPReadWrite SNMPAccess = "rw"
+)
+
+// SNMPCommunity is an SNMPv1/v2c community.
+type SNMPCommunity struct {
+	// Name is the community string. It acts as a shared secret, so avoid
+	// logging it; String omits it.
+	Name string `xml:"name" json:"name" yaml:"name"`
+	// Access defaults to SNMPReadOnly.
+	Access SNMPAccess `xml:"access" json:"access" yaml:"access"`
+}
+
+func (c SNMPCommunity) String() string {
+	access := c.Access
+	if access == "" {
+		access = SNMPReadOnly
+	}
+	return fmt.Sprintf("community (%s)", access)
+}
+
+// SNMPSecurityLevel is the security level of an SNMPv3 user.
+type SNMPSecurityLevel string
+
+const (
+	SNMPNoAuthNoPriv SNMPSecurityLevel = "noAuthNoPriv"
+	SNMPAuthNoPriv   SNMPSecurityLevel = "authNoPriv"
+	SNMPAuthPriv     SNMPSecurityLevel = "authPriv"
+)
+
+// SNMPAuthProtocol is the authentication protocol of an SNMPv3 user.
+type SNMPAuthProtocol string
+
+const (
+	SNMPAuthMD5 SNMPAuthProtocol = "MD5"
+	SNMPAuthSHA SNMPAuthProtocol = "SHA"
+)
+
+// SNMPPrivProtocol is the privacy (encryption) protocol of an SNMPv3 user.
+type SNMPPrivProtocol string
+
+const (
+	SNMPPrivDES SNMPPrivProtocol = "DES"
+	SNMPPrivAES SNMPPrivProtocol = "AES"
+)
+
+// SNMPUser is an SNMPv3 user. The passwords are never returned by GetSNMP;
+// leaving them empty in SetSNMP keeps the passwords of an existing user.
+type SNMPUser struct {
+	Name          string            `xml:"name" json:"name" yaml:"name"`
+	SecurityLevel SNMPSecurityLevel `xml:"securityLevel" json:"securityLevel" yaml:"securityLevel"`
+	AuthProtocol  SNMPAuthProtocol  `xml:"authProtocol" json:"authProtocol" yaml:"authProtocol"`
+	AuthPassword  string            `xml:"-" json:"-" yaml:"-"`
+	PrivProtocol  SNMPPrivProtocol  `xml:"privProtocol" json:"privProtocol" yaml:"privProtocol"`
+	PrivPassword  string            `xml:"-" json:"-" yaml:"-"`
+}
+
+// SNMPTrapTarget receives the traps sent by the NAS.
+type SNMPTrapTarget struct {
+	Address string `xml:"address" json:"address" yaml:"address"`
+	// Port defaults to 162.
+	Port    int         `xml:"port" json:"port" yaml:"port"`
+	Version SNMPVersion `xml:"version" json:"version" yaml:"version"`
+	// Community is used for SNMPv1/v2c traps, User for SNMPv3 traps.
+	Community string `xml:"community" json:"community" yaml:"community"`
+	User      string `xml:"user" json:"user" yaml:"user"`
+}
+
+// SNMPSettings are the settings of the SNMP service.
+type SNMPSettings struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	// Port defaults to DefaultSNMPPort.
+	Port int `xml:"port" json:"port" yaml:"port"`
+	// Versions lists the enabled protocol versions. Use only SNMPv3 to
+	// disable the community based versions.
+	Versions    []SNMPVersion    `xml:"versions" json:"versions" yaml:"versions"`
+	Communities []SNMPCommunity  `xml:"communities" json:"communities" yaml:"communities"`
+	Users       []SNMPUser       `xml:"users" json:"users" yaml:"users"`
+	TrapTargets []SNMPTrapTarget `xml:"trapTargets" json:"trapTargets" yaml:"trapTargets"`
+}
+
+// HasVersion reports whether the protocol version v is enabled.
+func (s SNMPSettings) HasVersion(v SNMPVersion) bool {
+	for _, version := range s.Versions {
+		if version == v {
+			return true
+		}
+	}
+	return false
+}
+
+type SNMPResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	SNMPSettings      `yaml:",inline"`
+}
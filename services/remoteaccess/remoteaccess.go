@@ -0,0 +1,563 @@
+package remoteaccess
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// GetSSH returns the settings of the SSH service.
+func (client Client) GetSSH(ctx context.Context) (result SSHResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSSH")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSSHPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetSSH", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetSSHSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetSSH", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetSSHResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetSSH", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSSHPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "ssh_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetSSHSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetSSHResponder(resp *http.Response) (result SSHResponse, err error) {
+	var doc qdocService
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Enabled = doc.Func.OwnContent.Enable == "1"
+	result.Port = parseInt(doc.Func.OwnContent.Port)
+	result.SFTP = doc.Func.OwnContent.SFTP == "1"
+
+	return
+}
+
+// SetSSH replaces the settings of the SSH service.
+func (client Client) SetSSH(ctx context.Context, settings SSHSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetSSH")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetSSHPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetSSH", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetSSHSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetSSH", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetSSHResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetSSH", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetSSHPreparer(ctx context.Context, settings SSHSettings) (*http.Request, error) {
+	port := settings.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":     []string{"ssh_setting"},
+			"func":        []string{"set_setting"},
+			"enable":      []string{boolString(settings.Enabled)},
+			"port":        []string{strconv.Itoa(port)},
+			"sftp_enable": []string{boolString(settings.SFTP)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetSSHSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetSSHResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetTelnet returns the settings of the Telnet service.
+func (client Client) GetTelnet(ctx context.Context) (result TelnetResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetTelnet")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetTelnetPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetTelnet", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetTelnetSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetTelnet", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetTelnetResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetTelnet", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetTelnetPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "telnet_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetTelnetSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetTelnetResponder(resp *http.Response) (result TelnetResponse, err error) {
+	var doc qdocService
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Enabled = doc.Func.OwnContent.Enable == "1"
+	result.Port = parseInt(doc.Func.OwnContent.Port)
+
+	return
+}
+
+// SetTelnet replaces the settings of the Telnet service.
+func (client Client) SetTelnet(ctx context.Context, settings TelnetSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetTelnet")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetTelnetPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetTelnet", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetTelnetSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetTelnet", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetTelnetResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetTelnet", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetTelnetPreparer(ctx context.Context, settings TelnetSettings) (*http.Request, error) {
+	port := settings.Port
+	if port == 0 {
+		port = DefaultTelnetPort
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"telnet_setting"},
+			"func":    []string{"set_setting"},
+			"enable":  []string{boolString(settings.Enabled)},
+			"port":    []string{strconv.Itoa(port)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetTelnetSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetTelnetResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetSNMP returns the settings of the SNMP service. The passwords of SNMPv3
+// users are not returned.
+func (client Client) GetSNMP(ctx context.Context) (result SNMPResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSNMP")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSNMPPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetSNMP", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetSNMPSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetSNMP", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetSNMPResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "GetSNMP", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSNMPPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "snmp_setting",
+		"func":    "get_setting",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetSNMPSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetSNMPResponder(resp *http.Response) (result SNMPResponse, err error) {
+	var doc qdocSNMP
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	c := doc.Func.OwnContent
+	result.Enabled = c.Enable == "1"
+	result.Port = parseInt(c.Port)
+	for _, v := range c.Versions {
+		result.Versions = append(result.Versions, SNMPVersion(v))
+	}
+	for _, community := range c.Communities {
+		result.Communities = append(result.Communities, SNMPCommunity{
+			Name:   community.Name,
+			Access: SNMPAccess(community.Access),
+		})
+	}
+	for _, user := range c.Users {
+		result.Users = append(result.Users, SNMPUser{
+			Name:          user.Name,
+			SecurityLevel: SNMPSecurityLevel(user.SecurityLevel),
+			AuthProtocol:  SNMPAuthProtocol(filterNullString(user.AuthProtocol)),
+			PrivProtocol:  SNMPPrivProtocol(filterNullString(user.PrivProtocol)),
+		})
+	}
+	for _, trap := range c.Traps {
+		result.TrapTargets = append(result.TrapTargets, SNMPTrapTarget{
+			Address:   trap.Address,
+			Port:      parseInt(trap.Port),
+			Version:   SNMPVersion(trap.Version),
+			Community: filterNullString(trap.Community),
+			User:      filterNullString(trap.User),
+		})
+	}
+
+	return
+}
+
+// SetSNMP replaces the settings of the SNMP service including its
+// communities, users and trap targets.
+func (client Client) SetSNMP(ctx context.Context, settings SNMPSettings) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetSNMP")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetSNMPPreparer(ctx, settings)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetSNMP", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetSNMPSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetSNMP", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetSNMPResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "remoteaccess.Client", "SetSNMP", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetSNMPPreparer(ctx context.Context, settings SNMPSettings) (*http.Request, error) {
+	port := settings.Port
+	if port == 0 {
+		port = DefaultSNMPPort
+	}
+	versions := make([]string, len(settings.Versions))
+	for i, v := range settings.Versions {
+		versions[i] = string(v)
+	}
+
+	data := url.Values{
+		"subfunc":  []string{"snmp_setting"},
+		"func":     []string{"set_setting"},
+		"enable":   []string{boolString(settings.Enabled)},
+		"port":     []string{strconv.Itoa(port)},
+		"versions": []string{strings.Join(versions, ",")},
+	}
+	// Like the other list settings of QTS, every entry is sent as a set of
+	// indexed fields, so optional fields such as the passwords can be omitted
+	// without shifting the fields of the following entries.
+	data.Set("community_count", strconv.Itoa(len(settings.Communities)))
+	for i, community := range settings.Communities {
+		n := strconv.Itoa(i)
+		access := community.Access
+		if access == "" {
+			access = SNMPReadOnly
+		}
+		data.Set("community_name"+n, community.Name)
+		data.Set("community_access"+n, string(access))
+	}
+	data.Set("user_count", strconv.Itoa(len(settings.Users)))
+	for i, user := range settings.Users {
+		n := strconv.Itoa(i)
+		data.Set("user_name"+n, user.Name)
+		data.Set("user_level"+n, string(user.SecurityLevel))
+		data.Set("user_auth_proto"+n, string(user.AuthProtocol))
+		data.Set("user_priv_proto"+n, string(user.PrivProtocol))
+		// empty passwords keep the existing ones
+		if user.AuthPassword != "" {
+			data.Set("user_auth_pwd"+n, base64.StdEncoding.EncodeToString([]byte(user.AuthPassword)))
+		}
+		if user.PrivPassword != "" {
+			data.Set("user_priv_pwd"+n, base64.StdEncoding.EncodeToString([]byte(user.PrivPassword)))
+		}
+	}
+	data.Set("trap_count", strconv.Itoa(len(settings.TrapTargets)))
+	for i, trap := range settings.TrapTargets {
+		n := strconv.Itoa(i)
+		trapPort := trap.Port
+		if trapPort == 0 {
+			trapPort = 162
+		}
+		data.Set("trap_address"+n, trap.Address)
+		data.Set("trap_port"+n, strconv.Itoa(trapPort))
+		data.Set("trap_version"+n, string(trap.Version))
+		data.Set("trap_community"+n, trap.Community)
+		data.Set("trap_user"+n, trap.User)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/sys/sysRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetSNMPSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetSNMPResponder(resp *http.Response) (err error) {
+	var doc qdocServiceOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
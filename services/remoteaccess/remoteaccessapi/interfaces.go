@@ -0,0 +1,19 @@
+package remoteaccessapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/remoteaccess"
+)
+
+// RemoteAccessClientAPI contains the set of methods on the remoteaccess.Client type.
+type RemoteAccessClientAPI interface {
+	GetSSH(ctx context.Context) (remoteaccess.SSHResponse, error)
+	SetSSH(ctx context.Context, settings remoteaccess.SSHSettings) error
+	GetTelnet(ctx context.Context) (remoteaccess.TelnetResponse, error)
+	SetTelnet(ctx context.Context, settings remoteaccess.TelnetSettings) error
+	GetSNMP(ctx context.Context) (remoteaccess.SNMPResponse, error)
+	SetSNMP(ctx context.Context, settings remoteaccess.SNMPSettings) error
+}
+
+var _ RemoteAccessClientAPI = (*remoteaccess.Client)(nil)
@@ -0,0 +1,145 @@
+package sharedfolders
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/sharedfolders"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+func parseUint(s string) uint64 {
+	u, err := strconv.ParseUint(strings.TrimSpace(filterNullString(s)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return u
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+type qdocFolderList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Share []struct {
+				Name       string `xml:"sharename"`
+				Volume     string `xml:"volume"`
+				Path       string `xml:"path"`
+				Comment    string `xml:"comment"`
+				Quota      string `xml:"quota_bytes"`
+				RecycleBin string `xml:"recycle_bin"`
+				Encrypted  string `xml:"encrypted"`
+				Hidden     string `xml:"hidden"`
+			} `xml:"share"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocPermissions struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			ACL []struct {
+				Name   string `xml:"name"`
+				Type   string `xml:"type"`
+				Access string `xml:"access"`
+			} `xml:"acl"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocFolderOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+// SharedFolder is a shared folder of the NAS.
+type SharedFolder struct {
+	Name    string `xml:"name" json:"name" yaml:"name"`
+	Volume  string `xml:"volume" json:"volume" yaml:"volume"`
+	Path    string `xml:"path" json:"path" yaml:"path"`
+	Comment string `xml:"comment,omitempty" json:"comment,omitempty" yaml:"comment,omitempty"`
+	// QuotaBytes limits the size of the folder. Zero means no limit.
+	QuotaBytes uint64 `xml:"quotaBytes" json:"quotaBytes" yaml:"quotaBytes"`
+	RecycleBin bool   `xml:"recycleBin" json:"recycleBin" yaml:"recycleBin"`
+	Encrypted  bool   `xml:"encrypted" json:"encrypted" yaml:"encrypted"`
+	Hidden     bool   `xml:"hidden" json:"hidden" yaml:"hidden"`
+}
+
+type ListResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Folders           []SharedFolder
+}
+
+// CreateOptions describes a new shared folder.
+type CreateOptions struct {
+	Name    string
+	Volume  string
+	Comment string
+	// QuotaBytes limits the size of the folder. Zero means no limit.
+	QuotaBytes uint64
+	RecycleBin bool
+	Hidden     bool
+	// Encrypted enables folder encryption with EncryptionPassword.
+	Encrypted          bool
+	EncryptionPassword string
+}
+
+// UpdateOptions changes the settings of a shared folder. Settings which are
+// nil are left unchanged.
+type UpdateOptions struct {
+	Comment *string
+	// QuotaBytes limits the size of the folder. Zero means no limit.
+	QuotaBytes *uint64
+	RecycleBin *bool
+	Hidden     *bool
+}
+
+// AccessRight is the permission of a user or group on a shared folder.
+type AccessRight string
+
+const (
+	AccessRightNone      AccessRight = ""
+	AccessRightReadOnly  AccessRight = "ro"
+	AccessRightReadWrite AccessRight = "rw"
+	AccessRightDeny      AccessRight = "deny"
+)
+
+// PrincipalType is the kind of principal of an ACLEntry.
+type PrincipalType string
+
+const (
+	PrincipalTypeUser  PrincipalType = "user"
+	PrincipalTypeGroup PrincipalType = "group"
+)
+
+// ACLEntry grants an AccessRight on a shared folder to a user or group.
+// Entries with AccessRightNone remove the permission of the principal.
+type ACLEntry struct {
+	Principal     string        `xml:"principal" json:"principal" yaml:"principal"`
+	PrincipalType PrincipalType `xml:"principalType" json:"principalType" yaml:"principalType"`
+	Access        AccessRight   `xml:"access" json:"access" yaml:"access"`
+}
+
+type PermissionsResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Entries           []ACLEntry
+}
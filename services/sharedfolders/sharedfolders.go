@@ -0,0 +1,525 @@
+package sharedfolders
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// List returns all shared folders.
+func (client Client) List(ctx context.Context) (result ListResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.List")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "List", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "List", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "List", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "share_folder",
+		"func":    "get_list",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListResponder(resp *http.Response) (result ListResponse, err error) {
+	var doc qdocFolderList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Folders = make([]SharedFolder, len(doc.Func.OwnContent.Share))
+	for i, share := range doc.Func.OwnContent.Share {
+		result.Folders[i] = SharedFolder{
+			Name:       share.Name,
+			Volume:     filterNullString(share.Volume),
+			Path:       filterNullString(share.Path),
+			Comment:    filterNullString(share.Comment),
+			QuotaBytes: parseUint(share.Quota),
+			RecycleBin: share.RecycleBin == "1",
+			Encrypted:  share.Encrypted == "1",
+			Hidden:     share.Hidden == "1",
+		}
+	}
+
+	return
+}
+
+// Create creates a new shared folder.
+func (client Client) Create(ctx context.Context, opts CreateOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Create")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CreatePreparer(ctx, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Create", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Create", resp, "Failure sending request")
+		return
+	}
+
+	err = client.CreateResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Create", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CreatePreparer(ctx context.Context, opts CreateOptions) (*http.Request, error) {
+	data := url.Values{
+		"subfunc":     []string{"share_folder"},
+		"func":        []string{"add_share"},
+		"sharename":   []string{opts.Name},
+		"volume":      []string{opts.Volume},
+		"comment":     []string{opts.Comment},
+		"quota_bytes": []string{strconv.FormatUint(opts.QuotaBytes, 10)},
+		"recycle_bin": []string{boolString(opts.RecycleBin)},
+		"hidden":      []string{boolString(opts.Hidden)},
+		"encrypted":   []string{boolString(opts.Encrypted)},
+	}
+	if opts.Encrypted {
+		data.Set("encrypt_pwd", opts.EncryptionPassword)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CreateSender sends the request without retries, as a retry after a lost
+// response would fail because the folder already exists.
+func (client Client) CreateSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CreateResponder(resp *http.Response) (err error) {
+	var doc qdocFolderOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("shared folder operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// Update changes the settings of the shared folder name which are set in
+// opts.
+func (client Client) Update(ctx context.Context, name string, opts UpdateOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Update")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.UpdatePreparer(ctx, name, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Update", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.UpdateSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Update", resp, "Failure sending request")
+		return
+	}
+
+	err = client.UpdateResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Update", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) UpdatePreparer(ctx context.Context, name string, opts UpdateOptions) (*http.Request, error) {
+	data := url.Values{
+		"subfunc":   []string{"share_folder"},
+		"func":      []string{"modify_share"},
+		"sharename": []string{name},
+	}
+	if opts.Comment != nil {
+		data.Set("comment", *opts.Comment)
+	}
+	if opts.QuotaBytes != nil {
+		data.Set("quota_bytes", strconv.FormatUint(*opts.QuotaBytes, 10))
+	}
+	if opts.RecycleBin != nil {
+		data.Set("recycle_bin", boolString(*opts.RecycleBin))
+	}
+	if opts.Hidden != nil {
+		data.Set("hidden", boolString(*opts.Hidden))
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// UpdateSender sends the request without retries, like the other requests
+// changing a shared folder.
+func (client Client) UpdateSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) UpdateResponder(resp *http.Response) (err error) {
+	var doc qdocFolderOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("shared folder operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// Delete removes the shared folder name. The data of the folder is only
+// removed if deleteData is set.
+func (client Client) Delete(ctx context.Context, name string, deleteData bool) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Delete")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DeletePreparer(ctx, name, deleteData)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Delete", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Delete", resp, "Failure sending request")
+		return
+	}
+
+	err = client.DeleteResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "Delete", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) DeletePreparer(ctx context.Context, name string, deleteData bool) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":   []string{"share_folder"},
+			"func":      []string{"del_share"},
+			"sharename": []string{name},
+			"del_data":  []string{boolString(deleteData)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// DeleteSender sends the request without retries, as the folder and its
+// data may already be deleted when the response is lost.
+func (client Client) DeleteSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) DeleteResponder(resp *http.Response) (err error) {
+	var doc qdocFolderOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("shared folder operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetPermissions returns the user and group permissions of the shared
+// folder name.
+func (client Client) GetPermissions(ctx context.Context, name string) (result PermissionsResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetPermissions")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetPermissionsPreparer(ctx, name)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "GetPermissions", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetPermissionsSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "GetPermissions", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetPermissionsResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "GetPermissions", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetPermissionsPreparer(ctx context.Context, name string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc":   "share_folder",
+		"func":      "get_share_privilege",
+		"sharename": autorest.Encode("query", name),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetPermissionsSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetPermissionsResponder(resp *http.Response) (result PermissionsResponse, err error) {
+	var doc qdocPermissions
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Entries = make([]ACLEntry, len(doc.Func.OwnContent.ACL))
+	for i, acl := range doc.Func.OwnContent.ACL {
+		result.Entries[i] = ACLEntry{
+			Principal:     acl.Name,
+			PrincipalType: PrincipalType(acl.Type),
+			Access:        AccessRight(filterNullString(acl.Access)),
+		}
+	}
+
+	return
+}
+
+// SetPermissions sets the permissions of the given users and groups on the
+// shared folder name. Principals not contained in entries are left unchanged.
+func (client Client) SetPermissions(ctx context.Context, name string, entries []ACLEntry) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetPermissions")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetPermissionsPreparer(ctx, name, entries)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "SetPermissions", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetPermissionsSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "SetPermissions", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetPermissionsResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "sharedfolders.Client", "SetPermissions", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetPermissionsPreparer(ctx context.Context, name string, entries []ACLEntry) (*http.Request, error) {
+	data := url.Values{
+		"subfunc":   []string{"share_folder"},
+		"func":      []string{"set_share_privilege"},
+		"sharename": []string{name},
+		"count":     []string{strconv.Itoa(len(entries))},
+	}
+	for i, entry := range entries {
+		n := strconv.Itoa(i)
+		data.Set("name"+n, entry.Principal)
+		data.Set("type"+n, string(entry.PrincipalType))
+		data.Set("access"+n, string(entry.Access))
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// SetPermissionsSender sends the request without retries, like the other
+// requests changing a shared folder.
+func (client Client) SetPermissionsSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) SetPermissionsResponder(resp *http.Response) (err error) {
+	var doc qdocFolderOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("shared folder operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
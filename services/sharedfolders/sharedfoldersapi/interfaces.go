@@ -0,0 +1,19 @@
+package sharedfoldersapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/sharedfolders"
+)
+
+// SharedFoldersClientAPI contains the set of methods on the sharedfolders.Client type.
+type SharedFoldersClientAPI interface {
+	List(ctx context.Context) (sharedfolders.ListResponse, error)
+	Create(ctx context.Context, opts sharedfolders.CreateOptions) error
+	Update(ctx context.Context, name string, opts sharedfolders.UpdateOptions) error
+	Delete(ctx context.Context, name string, deleteData bool) error
+	GetPermissions(ctx context.Context, name string) (sharedfolders.PermissionsResponse, error)
+	SetPermissions(ctx context.Context, name string, entries []sharedfolders.ACLEntry) error
+}
+
+var _ SharedFoldersClientAPI = (*sharedfolders.Client)(nil)
@@ -0,0 +1,197 @@
+package snapshots
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/snapshots"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+func parseInt(s string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(filterNullString(s)))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+func parseUint(s string) uint64 {
+	u, err := strconv.ParseUint(strings.TrimSpace(filterNullString(s)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return u
+}
+
+// TargetType is the kind of object a snapshot is taken of.
+type TargetType string
+
+const (
+	TargetTypeVolume TargetType = "volume"
+	TargetTypeLUN    TargetType = "lun"
+)
+
+// Target identifies a volume or LUN.
+type Target struct {
+	Type TargetType `xml:"type" json:"type" yaml:"type"`
+	ID   string     `xml:"id" json:"id" yaml:"id"`
+}
+
+type qdocSnapshotList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Snapshots  struct {
+		Row []struct {
+			SnapshotID  string `xml:"snapshotID"`
+			Name        string `xml:"name"`
+			Description string `xml:"description"`
+			CreateTime  string `xml:"create_time"`
+			Size        string `xml:"size_bytes"`
+			Vital       string `xml:"vital"`
+			Status      string `xml:"status"`
+		} `xml:"row"`
+	} `xml:"Snapshot_List"`
+}
+
+type qdocSnapshotOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+	SnapshotID string   `xml:"snapshotID"`
+	TaskID     string   `xml:"taskID"`
+}
+
+type qdocTaskStatus struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Task       struct {
+		TaskID   string `xml:"taskID"`
+		Status   string `xml:"status"`
+		Progress string `xml:"progress"`
+		Result   string `xml:"result"`
+	} `xml:"Task_Info"`
+}
+
+type qdocSchedule struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Schedule   struct {
+		Enable      string `xml:"enable"`
+		Frequency   string `xml:"frequency"`
+		Interval    string `xml:"interval"`
+		Hour        string `xml:"hour"`
+		Minute      string `xml:"minute"`
+		Weekday     string `xml:"weekday"`
+		MonthDay    string `xml:"monthday"`
+		MaxCount    string `xml:"max_count"`
+		MaxDays     string `xml:"max_days"`
+		SmartSnap   string `xml:"smart_snapshot"`
+		Vital       string `xml:"vital"`
+		Description string `xml:"description"`
+	} `xml:"Schedule_Info"`
+}
+
+// Snapshot is a snapshot of a volume or LUN.
+type Snapshot struct {
+	ID          string `xml:"id" json:"id" yaml:"id"`
+	Name        string `xml:"name" json:"name" yaml:"name"`
+	Description string `xml:"description,omitempty" json:"description,omitempty" yaml:"description,omitempty"`
+	Target      Target `xml:"target" json:"target" yaml:"target"`
+	// CreateTime is the creation time as reported by QTS.
+	CreateTime string `xml:"createTime" json:"createTime" yaml:"createTime"`
+	SizeBytes  uint64 `xml:"sizeBytes" json:"sizeBytes" yaml:"sizeBytes"`
+	// KeepForever excludes the snapshot from the retention policy.
+	KeepForever bool   `xml:"keepForever" json:"keepForever" yaml:"keepForever"`
+	Status      string `xml:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type ListResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Snapshots         []Snapshot
+}
+
+// CreateOptions describes a new snapshot.
+type CreateOptions struct {
+	Name        string
+	Description string
+	// KeepForever excludes the snapshot from the retention policy.
+	KeepForever bool
+}
+
+type CreateResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	SnapshotID        string
+	// TaskID identifies the snapshot job on the NAS.
+	TaskID string
+}
+
+// TaskStatus is the state of a snapshot job.
+type TaskStatus string
+
+const (
+	TaskStatusRunning  TaskStatus = "running"
+	TaskStatusFinished TaskStatus = "finished"
+	TaskStatusFailed   TaskStatus = "failed"
+)
+
+type TaskStatusResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	TaskID            string
+	Status            TaskStatus
+	// Progress of the job in percent.
+	Progress int
+}
+
+// ScheduleFrequency is the frequency of scheduled snapshots.
+type ScheduleFrequency string
+
+const (
+	ScheduleFrequencyHourly  ScheduleFrequency = "hourly"
+	ScheduleFrequencyDaily   ScheduleFrequency = "daily"
+	ScheduleFrequencyWeekly  ScheduleFrequency = "weekly"
+	ScheduleFrequencyMonthly ScheduleFrequency = "monthly"
+)
+
+// Schedule describes the snapshot schedule and retention of a volume or LUN.
+type Schedule struct {
+	Enabled   bool              `xml:"enabled" json:"enabled" yaml:"enabled"`
+	Frequency ScheduleFrequency `xml:"frequency" json:"frequency" yaml:"frequency"`
+	// Interval is the number of hours between hourly snapshots.
+	Interval int `xml:"interval,omitempty" json:"interval,omitempty" yaml:"interval,omitempty"`
+	Hour     int `xml:"hour" json:"hour" yaml:"hour"`
+	Minute   int `xml:"minute" json:"minute" yaml:"minute"`
+	// Weekday is the day of weekly snapshots, 0 being Sunday.
+	Weekday int `xml:"weekday,omitempty" json:"weekday,omitempty" yaml:"weekday,omitempty"`
+	// MonthDay is the day of monthly snapshots.
+	MonthDay int `xml:"monthDay,omitempty" json:"monthDay,omitempty" yaml:"monthDay,omitempty"`
+	// MaxCount is the maximum number of snapshots to keep, MaxDays the
+	// maximum age in days. Zero keeps snapshots without limit.
+	MaxCount int `xml:"maxCount" json:"maxCount" yaml:"maxCount"`
+	MaxDays  int `xml:"maxDays" json:"maxDays" yaml:"maxDays"`
+	// SmartSnapshot skips snapshots if the data has not changed.
+	SmartSnapshot bool `xml:"smartSnapshot" json:"smartSnapshot" yaml:"smartSnapshot"`
+	// KeepForever excludes scheduled snapshots from the retention policy.
+	KeepForever bool   `xml:"keepForever" json:"keepForever" yaml:"keepForever"`
+	Description string `xml:"description,omitempty" json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type ScheduleResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Schedule          `yaml:",inline"`
+}
+
+type OperationResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	// TaskID identifies the snapshot job on the NAS.
+	TaskID string
+}
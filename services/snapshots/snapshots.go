@@ -0,0 +1,700 @@
+package snapshots
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+const (
+	// pollInterval is the time between two task status requests.
+	pollInterval = 5 * time.Second
+	// taskTimeout is the maximum time to wait for a snapshot job.
+	taskTimeout = 30 * time.Minute
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// List returns the snapshots of a volume or LUN.
+func (client Client) List(ctx context.Context, target Target) (result ListResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.List")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListPreparer(ctx, target)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "List", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "List", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "List", resp, "Failure responding to request")
+		return
+	}
+
+	for i := range result.Snapshots {
+		result.Snapshots[i].Target = target
+	}
+
+	return
+}
+
+func (client Client) ListPreparer(ctx context.Context, target Target) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func": "get_snapshot_list",
+		"type": string(target.Type),
+		"id":   autorest.Encode("query", target.ID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListResponder(resp *http.Response) (result ListResponse, err error) {
+	var doc qdocSnapshotList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Snapshots = make([]Snapshot, len(doc.Snapshots.Row))
+	for i, row := range doc.Snapshots.Row {
+		result.Snapshots[i] = Snapshot{
+			ID:          row.SnapshotID,
+			Name:        filterNullString(row.Name),
+			Description: filterNullString(row.Description),
+			CreateTime:  filterNullString(row.CreateTime),
+			SizeBytes:   parseUint(row.Size),
+			KeepForever: row.Vital == "1",
+			Status:      filterNullString(row.Status),
+		}
+	}
+
+	return
+}
+
+// Create takes a snapshot of a volume or LUN. Unless dontWait is set, Create
+// waits until the snapshot job has finished.
+func (client Client) Create(ctx context.Context, target Target, opts CreateOptions, dontWait bool) (result CreateResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Create")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CreatePreparer(ctx, target, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Create", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Create", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.CreateResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Create", resp, "Failure responding to request")
+		return
+	}
+
+	if dontWait {
+		return
+	}
+	if result.TaskID != "" {
+		err = client.waitForTask(ctx, result.TaskID)
+		return
+	}
+
+	// Without a task the snapshot has to exist already.
+	list, err := client.List(ctx, target)
+	if err != nil {
+		return
+	}
+	for _, snapshot := range list.Snapshots {
+		if (result.SnapshotID != "" && snapshot.ID == result.SnapshotID) ||
+			(result.SnapshotID == "" && snapshot.Name == opts.Name) {
+			result.SnapshotID = snapshot.ID
+			return
+		}
+	}
+	err = fmt.Errorf("snapshot %s not found and no snapshot task reported", opts.Name)
+	return
+}
+
+func (client Client) CreatePreparer(ctx context.Context, target Target, opts CreateOptions) (*http.Request, error) {
+	vital := "0"
+	if opts.KeepForever {
+		vital = "1"
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithFormData(url.Values{
+			"func":        []string{"create_snapshot"},
+			"type":        []string{string(target.Type)},
+			"id":          []string{target.ID},
+			"name":        []string{opts.Name},
+			"description": []string{opts.Description},
+			"vital":       []string{vital},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CreateSender sends the request without retries, as a retry after a lost
+// response would create a second snapshot.
+func (client Client) CreateSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CreateResponder(resp *http.Response) (result CreateResponse, err error) {
+	var doc qdocSnapshotOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("snapshot operation failed with result %s", doc.Result)
+		return
+	}
+
+	result.SnapshotID = doc.SnapshotID
+	result.TaskID = doc.TaskID
+
+	return
+}
+
+// Delete removes a snapshot. Unless dontWait is set, Delete waits until the
+// snapshot job has finished.
+func (client Client) Delete(ctx context.Context, snapshotID string, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Delete")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DeletePreparer(ctx, snapshotID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Delete", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Delete", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.DeleteResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Delete", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		err = client.waitForTask(ctx, result.TaskID)
+	}
+
+	return
+}
+
+func (client Client) DeletePreparer(ctx context.Context, snapshotID string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithFormData(url.Values{
+			"func":       []string{"delete_snapshot"},
+			"snapshotID": []string{snapshotID},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// DeleteSender sends the request without retries, so a deletion which has
+// already started is not requested again.
+func (client Client) DeleteSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) DeleteResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc qdocSnapshotOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("snapshot operation failed with result %s", doc.Result)
+		return
+	}
+
+	result.TaskID = doc.TaskID
+
+	return
+}
+
+// Revert restores the volume or LUN to the state of the snapshot. Unless
+// dontWait is set, Revert waits until the snapshot job has finished.
+func (client Client) Revert(ctx context.Context, snapshotID string, dontWait bool) (result OperationResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.Revert")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.RevertPreparer(ctx, snapshotID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Revert", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.RevertSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Revert", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.RevertResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "Revert", resp, "Failure responding to request")
+		return
+	}
+
+	if !dontWait && result.TaskID != "" {
+		err = client.waitForTask(ctx, result.TaskID)
+	}
+
+	return
+}
+
+func (client Client) RevertPreparer(ctx context.Context, snapshotID string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithFormData(url.Values{
+			"func":       []string{"revert_snapshot"},
+			"snapshotID": []string{snapshotID},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// RevertSender sends the request without retries, as a repeated revert would
+// discard the data written since the first one.
+func (client Client) RevertSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) RevertResponder(resp *http.Response) (result OperationResponse, err error) {
+	var doc qdocSnapshotOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("snapshot operation failed with result %s", doc.Result)
+		return
+	}
+
+	result.TaskID = doc.TaskID
+
+	return
+}
+
+// GetTaskStatus returns the state of a snapshot job.
+func (client Client) GetTaskStatus(ctx context.Context, taskID string) (result TaskStatusResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetTaskStatus")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetTaskStatusPreparer(ctx, taskID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "GetTaskStatus", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetTaskStatusSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "GetTaskStatus", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetTaskStatusResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "GetTaskStatus", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetTaskStatusPreparer(ctx context.Context, taskID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":   "get_task_status",
+		"taskID": autorest.Encode("query", taskID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetTaskStatusSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetTaskStatusResponder(resp *http.Response) (result TaskStatusResponse, err error) {
+	var doc qdocTaskStatus
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.TaskID = doc.Task.TaskID
+	result.Progress = parseInt(doc.Task.Progress)
+	switch doc.Task.Status {
+	case "0", "running":
+		result.Status = TaskStatusRunning
+	case "1", "finished", "done":
+		result.Status = TaskStatusFinished
+		if doc.Task.Result != "" && doc.Task.Result != "0" {
+			result.Status = TaskStatusFailed
+		}
+	case "-1", "failed", "error":
+		result.Status = TaskStatusFailed
+	default:
+		err = fmt.Errorf("unknown snapshot task status %q", doc.Task.Status)
+	}
+
+	return
+}
+
+// GetSchedule returns the snapshot schedule of a volume or LUN.
+func (client Client) GetSchedule(ctx context.Context, target Target) (result ScheduleResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSchedule")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSchedulePreparer(ctx, target)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "GetSchedule", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetScheduleSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "GetSchedule", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetScheduleResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "GetSchedule", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSchedulePreparer(ctx context.Context, target Target) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func": "get_schedule",
+		"type": string(target.Type),
+		"id":   autorest.Encode("query", target.ID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetScheduleSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetScheduleResponder(resp *http.Response) (result ScheduleResponse, err error) {
+	var doc qdocSchedule
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	sched := doc.Schedule
+	result.Schedule = Schedule{
+		Enabled:       sched.Enable == "1",
+		Frequency:     ScheduleFrequency(filterNullString(sched.Frequency)),
+		Interval:      parseInt(sched.Interval),
+		Hour:          parseInt(sched.Hour),
+		Minute:        parseInt(sched.Minute),
+		Weekday:       parseInt(sched.Weekday),
+		MonthDay:      parseInt(sched.MonthDay),
+		MaxCount:      parseInt(sched.MaxCount),
+		MaxDays:       parseInt(sched.MaxDays),
+		SmartSnapshot: sched.SmartSnap == "1",
+		KeepForever:   sched.Vital == "1",
+		Description:   filterNullString(sched.Description),
+	}
+
+	return
+}
+
+// SetSchedule replaces the snapshot schedule of a volume or LUN.
+func (client Client) SetSchedule(ctx context.Context, target Target, schedule Schedule) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetSchedule")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetSchedulePreparer(ctx, target, schedule)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "SetSchedule", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetScheduleSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "SetSchedule", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetScheduleResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "snapshots.Client", "SetSchedule", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetSchedulePreparer(ctx context.Context, target Target, schedule Schedule) (*http.Request, error) {
+	data := url.Values{
+		"func":           []string{"set_schedule"},
+		"type":           []string{string(target.Type)},
+		"id":             []string{target.ID},
+		"enable":         []string{boolString(schedule.Enabled)},
+		"frequency":      []string{string(schedule.Frequency)},
+		"interval":       []string{strconv.Itoa(schedule.Interval)},
+		"hour":           []string{strconv.Itoa(schedule.Hour)},
+		"minute":         []string{strconv.Itoa(schedule.Minute)},
+		"weekday":        []string{strconv.Itoa(schedule.Weekday)},
+		"monthday":       []string{strconv.Itoa(schedule.MonthDay)},
+		"max_count":      []string{strconv.Itoa(schedule.MaxCount)},
+		"max_days":       []string{strconv.Itoa(schedule.MaxDays)},
+		"smart_snapshot": []string{boolString(schedule.SmartSnapshot)},
+		"vital":          []string{boolString(schedule.KeepForever)},
+		"description":    []string{schedule.Description},
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/snapshot.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) SetScheduleSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) SetScheduleResponder(resp *http.Response) (err error) {
+	var doc qdocSnapshotOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("snapshot operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// waitForTask polls the snapshot job until it has finished.
+func (client Client) waitForTask(ctx context.Context, taskID string) error {
+	deadline := time.Now().Add(taskTimeout)
+	for time.Now().Before(deadline) {
+		stat, err := client.GetTaskStatus(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		switch stat.Status {
+		case TaskStatusFinished:
+			return nil
+		case TaskStatusFailed:
+			return fmt.Errorf("snapshot task %s failed", taskID)
+		}
+
+		t := time.NewTimer(pollInterval)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+	}
+
+	return fmt.Errorf("failed to wait for snapshot task %s", taskID)
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,20 @@
+package snapshotsapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/snapshots"
+)
+
+// SnapshotsClientAPI contains the set of methods on the snapshots.Client type.
+type SnapshotsClientAPI interface {
+	List(ctx context.Context, target snapshots.Target) (snapshots.ListResponse, error)
+	Create(ctx context.Context, target snapshots.Target, opts snapshots.CreateOptions, dontWait bool) (snapshots.CreateResponse, error)
+	Delete(ctx context.Context, snapshotID string, dontWait bool) (snapshots.OperationResponse, error)
+	Revert(ctx context.Context, snapshotID string, dontWait bool) (snapshots.OperationResponse, error)
+	GetTaskStatus(ctx context.Context, taskID string) (snapshots.TaskStatusResponse, error)
+	GetSchedule(ctx context.Context, target snapshots.Target) (snapshots.ScheduleResponse, error)
+	SetSchedule(ctx context.Context, target snapshots.Target, schedule snapshots.Schedule) error
+}
+
+var _ SnapshotsClientAPI = (*snapshots.Client)(nil)
@@ -0,0 +1,479 @@
+package storage
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/storage"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") || s == "--" {
+		return ""
+	}
+	return s
+}
+
+func parseInt(s string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(filterNullString(s)))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+// parseSize converts sizes like "3.64 TB" into bytes. Plain numbers are
+// interpreted as bytes.
+func parseSize(s string) uint64 {
+	fields := strings.Fields(filterNullString(s))
+	if len(fields) == 0 {
+		return 0
+	}
+
+	f, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+
+	unit := ""
+	if len(fields) > 1 {
+		unit = strings.ToUpper(fields[1])
+	}
+	switch strings.TrimSuffix(strings.TrimSuffix(unit, "B"), "I") {
+	case "K":
+		f *= 1 << 10
+	case "M":
+		f *= 1 << 20
+	case "G":
+		f *= 1 << 30
+	case "T":
+		f *= 1 << 40
+	case "P":
+		f *= 1 << 50
+	}
+
+	return uint64(f)
+}
+
+type qdocDiskList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	DiskInfo   struct {
+		Entry []struct {
+			HDNo        string `xml:"HDNo"`
+			EnclosureID string `xml:"Enclosure_ID"`
+			PortID      string `xml:"Port_ID"`
+			Model       string `xml:"Model"`
+			Serial      string `xml:"Serial"`
+			Capacity    string `xml:"Capacity"`
+			Temperature struct {
+				C string `xml:"oC"`
+			} `xml:"Temperature"`
+			Type   string `xml:"Type"`
+			Health string `xml:"Health"`
+			Status string `xml:"Status"`
+		} `xml:"entry"`
+	} `xml:"Disk_Info"`
+}
+
+type qdocSMART struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Health     string   `xml:"Health"`
+	SmartInfo  struct {
+		Entry []struct {
+			ID        string `xml:"ID"`
+			Name      string `xml:"Name"`
+			Value     string `xml:"Value"`
+			Worst     string `xml:"Worst"`
+			Threshold string `xml:"Thresh"`
+			RawData   string `xml:"RawData"`
+			Status    string `xml:"Status"`
+		} `xml:"entry"`
+	} `xml:"SMART_Info"`
+}
+
+type qdocSelfTest struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+	Test       struct {
+		Type     string `xml:"type"`
+		Status   string `xml:"status"`
+		Progress string `xml:"progress"`
+		Time     string `xml:"time"`
+	} `xml:"Test_Info"`
+}
+
+// DiskType is the kind of a disk.
+type DiskType string
+
+const (
+	DiskTypeUnknown DiskType = ""
+	DiskTypeHDD     DiskType = "hdd"
+	DiskTypeSSD     DiskType = "ssd"
+)
+
+// HealthStatus is the S.M.A.R.T. health verdict of a disk.
+type HealthStatus string
+
+const (
+	HealthStatusUnknown  HealthStatus = ""
+	HealthStatusGood     HealthStatus = "good"
+	HealthStatusWarning  HealthStatus = "warning"
+	HealthStatusAbnormal HealthStatus = "abnormal"
+)
+
+func parseHealthStatus(s string) HealthStatus {
+	switch strings.ToLower(strings.TrimSpace(filterNullString(s))) {
+	case "ok", "good", "normal":
+		return HealthStatusGood
+	case "warning":
+		return HealthStatusWarning
+	case "abnormal", "error", "failed", "bad":
+		return HealthStatusAbnormal
+	default:
+		return HealthStatusUnknown
+	}
+}
+
+// Disk describes a physical disk of the NAS or of an expansion enclosure.
+type Disk struct {
+	ID            string       `xml:"id" json:"id" yaml:"id"`
+	EnclosureID   string       `xml:"enclosureID" json:"enclosureID" yaml:"enclosureID"`
+	Bay           int          `xml:"bay" json:"bay" yaml:"bay"`
+	Model         string       `xml:"model" json:"model" yaml:"model"`
+	Serial        string       `xml:"serial" json:"serial" yaml:"serial"`
+	CapacityBytes uint64       `xml:"capacityBytes" json:"capacityBytes" yaml:"capacityBytes"`
+	TemperatureC  int          `xml:"temperatureC" json:"temperatureC" yaml:"temperatureC"`
+	Type          DiskType     `xml:"type" json:"type" yaml:"type"`
+	Health        HealthStatus `xml:"health" json:"health" yaml:"health"`
+	Status        string       `xml:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type DisksResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Disks             []Disk
+}
+
+// SMARTAttribute is a single S.M.A.R.T. attribute of a disk.
+type SMARTAttribute struct {
+	ID        int    `xml:"id" json:"id" yaml:"id"`
+	Name      string `xml:"name" json:"name" yaml:"name"`
+	Value     int    `xml:"value" json:"value" yaml:"value"`
+	Worst     int    `xml:"worst" json:"worst" yaml:"worst"`
+	Threshold int    `xml:"threshold" json:"threshold" yaml:"threshold"`
+	Raw       string `xml:"raw" json:"raw" yaml:"raw"`
+	Status    string `xml:"status,omitempty" json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type SMARTResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Health            HealthStatus
+	Attributes        []SMARTAttribute
+}
+
+// SelfTestType selects the S.M.A.R.T. self-test to run.
+type SelfTestType string
+
+const (
+	SelfTestTypeQuick    SelfTestType = "quick"
+	SelfTestTypeComplete SelfTestType = "complete"
+)
+
+// SelfTestStatus is the state of the last S.M.A.R.T. self-test of a disk.
+type SelfTestStatus string
+
+const (
+	SelfTestStatusNone    SelfTestStatus = ""
+	SelfTestStatusRunning SelfTestStatus = "running"
+	SelfTestStatusPassed  SelfTestStatus = "passed"
+	SelfTestStatusFailed  SelfTestStatus = "failed"
+	SelfTestStatusAborted SelfTestStatus = "aborted"
+)
+
+func parseSelfTestStatus(s string) SelfTestStatus {
+	switch strings.ToLower(strings.TrimSpace(filterNullString(s))) {
+	case "running", "testing", "in progress":
+		return SelfTestStatusRunning
+	case "passed", "completed", "ok", "good":
+		return SelfTestStatusPassed
+	case "failed", "error", "abnormal":
+		return SelfTestStatusFailed
+	case "aborted", "interrupted", "canceled", "cancelled":
+		return SelfTestStatusAborted
+	default:
+		return SelfTestStatusNone
+	}
+}
+
+type SelfTestResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Type              SelfTestType
+	Status            SelfTestStatus
+	// Progress of a running test in percent.
+	Progress int
+	// Time of the last test as reported by QTS.
+	Time string
+}
+
+type qdocStorageOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+type qdocPoolList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	PoolIndex  struct {
+		Row []qdocPool `xml:"row"`
+	} `xml:"Pool_Index"`
+}
+
+type qdocPool struct {
+	PoolID         string `xml:"poolID"`
+	PoolName       string `xml:"pool_name"`
+	Status         string `xml:"pool_status"`
+	CapacityBytes  string `xml:"capacity_bytes"`
+	AllocatedBytes string `xml:"allocated_bytes"`
+	FreeBytes      string `xml:"freesize_bytes"`
+	Threshold      string `xml:"threshold"`
+	RAIDList       struct {
+		RAID []string `xml:"raidID"`
+	} `xml:"raid_list"`
+}
+
+type qdocRAIDList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	RAIDIndex  struct {
+		Row []qdocRAID `xml:"row"`
+	} `xml:"RAID_Index"`
+}
+
+type qdocRAID struct {
+	RAIDID        string `xml:"raidID"`
+	PoolID        string `xml:"poolID"`
+	Level         string `xml:"raid_level"`
+	Status        string `xml:"raid_status"`
+	SyncAction    string `xml:"sync_action"`
+	SyncProgress  string `xml:"sync_progress"`
+	CapacityBytes string `xml:"capacity_bytes"`
+	DiskList      struct {
+		Disk []string `xml:"HDNo"`
+	} `xml:"disk_list"`
+}
+
+type qdocVolumeList struct {
+	XMLName     xml.Name `xml:"QDocRoot"`
+	AuthPassed  int      `xml:"authPassed"`
+	VolumeIndex struct {
+		Row []qdocVolume `xml:"row"`
+	} `xml:"Volume_Index"`
+}
+
+type qdocVolume struct {
+	VolumeID      string `xml:"volumeValue"`
+	Label         string `xml:"volumeLabel"`
+	PoolID        string `xml:"poolID"`
+	Type          string `xml:"volume_type"`
+	Status        string `xml:"volumeStatus"`
+	FileSystem    string `xml:"filesystem"`
+	CapacityBytes string `xml:"capacity_bytes"`
+	UsedBytes     string `xml:"used_bytes"`
+	FreeBytes     string `xml:"freesize_bytes"`
+	Threshold     string `xml:"threshold"`
+}
+
+// Pool is a storage pool consisting of one or more RAID groups.
+type Pool struct {
+	ID            string `xml:"id" json:"id" yaml:"id"`
+	Name          string `xml:"name,omitempty" json:"name,omitempty" yaml:"name,omitempty"`
+	Status        string `xml:"status" json:"status" yaml:"status"`
+	CapacityBytes uint64 `xml:"capacityBytes" json:"capacityBytes" yaml:"capacityBytes"`
+	UsedBytes     uint64 `xml:"usedBytes" json:"usedBytes" yaml:"usedBytes"`
+	FreeBytes     uint64 `xml:"freeBytes" json:"freeBytes" yaml:"freeBytes"`
+	// ThresholdPercent is the usage at which QTS raises a capacity alert.
+	ThresholdPercent int      `xml:"thresholdPercent" json:"thresholdPercent" yaml:"thresholdPercent"`
+	RAIDGroupIDs     []string `xml:"raidGroupIDs" json:"raidGroupIDs" yaml:"raidGroupIDs"`
+}
+
+func newPool(p qdocPool) Pool {
+	return Pool{
+		ID:               p.PoolID,
+		Name:             filterNullString(p.PoolName),
+		Status:           filterNullString(p.Status),
+		CapacityBytes:    parseSize(p.CapacityBytes),
+		UsedBytes:        parseSize(p.AllocatedBytes),
+		FreeBytes:        parseSize(p.FreeBytes),
+		ThresholdPercent: parseInt(p.Threshold),
+		RAIDGroupIDs:     append([]string(nil), p.RAIDList.RAID...),
+	}
+}
+
+// RAIDLevel is the RAID level of a RAID group.
+type RAIDLevel string
+
+const (
+	RAIDLevelSingle RAIDLevel = "single"
+	RAIDLevelJBOD   RAIDLevel = "jbod"
+	RAIDLevel0      RAIDLevel = "0"
+	RAIDLevel1      RAIDLevel = "1"
+	RAIDLevel5      RAIDLevel = "5"
+	RAIDLevel6      RAIDLevel = "6"
+	RAIDLevel10     RAIDLevel = "10"
+	RAIDLevel50     RAIDLevel = "50"
+	RAIDLevel60     RAIDLevel = "60"
+	RAIDLevelTP     RAIDLevel = "tp"
+)
+
+// SyncAction is the background operation running on a RAID group.
+type SyncAction string
+
+const (
+	SyncActionIdle      SyncAction = "idle"
+	SyncActionRebuild   SyncAction = "rebuild"
+	SyncActionResync    SyncAction = "resync"
+	SyncActionMigrate   SyncAction = "migrate"
+	SyncActionScrubbing SyncAction = "scrubbing"
+)
+
+// RAIDGroup is a RAID group of a storage pool.
+type RAIDGroup struct {
+	ID         string     `xml:"id" json:"id" yaml:"id"`
+	PoolID     string     `xml:"poolID" json:"poolID" yaml:"poolID"`
+	Level      RAIDLevel  `xml:"level" json:"level" yaml:"level"`
+	Status     string     `xml:"status" json:"status" yaml:"status"`
+	SyncAction SyncAction `xml:"syncAction" json:"syncAction" yaml:"syncAction"`
+	// SyncProgress is the progress of the running SyncAction in percent.
+	SyncProgress  float64  `xml:"syncProgress" json:"syncProgress" yaml:"syncProgress"`
+	CapacityBytes uint64   `xml:"capacityBytes" json:"capacityBytes" yaml:"capacityBytes"`
+	MemberDisks   []string `xml:"memberDisks" json:"memberDisks" yaml:"memberDisks"`
+}
+
+func newRAIDGroup(r qdocRAID) RAIDGroup {
+	action := SyncAction(strings.ToLower(filterNullString(r.SyncAction)))
+	if action == "" {
+		action = SyncActionIdle
+	}
+
+	progress, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(filterNullString(r.SyncProgress)), "%"), 64)
+
+	return RAIDGroup{
+		ID:            r.RAIDID,
+		PoolID:        filterNullString(r.PoolID),
+		Level:         RAIDLevel(strings.ToLower(strings.TrimPrefix(strings.ToLower(filterNullString(r.Level)), "raid"))),
+		Status:        filterNullString(r.Status),
+		SyncAction:    action,
+		SyncProgress:  progress,
+		CapacityBytes: parseSize(r.CapacityBytes),
+		MemberDisks:   append([]string(nil), r.DiskList.Disk...),
+	}
+}
+
+// VolumeType is the provisioning type of a volume.
+type VolumeType string
+
+const (
+	VolumeTypeThick  VolumeType = "thick"
+	VolumeTypeThin   VolumeType = "thin"
+	VolumeTypeStatic VolumeType = "static"
+)
+
+// Volume is a logical volume of the NAS.
+type Volume struct {
+	ID            string     `xml:"id" json:"id" yaml:"id"`
+	Label         string     `xml:"label" json:"label" yaml:"label"`
+	PoolID        string     `xml:"poolID,omitempty" json:"poolID,omitempty" yaml:"poolID,omitempty"`
+	Type          VolumeType `xml:"type" json:"type" yaml:"type"`
+	Status        string     `xml:"status" json:"status" yaml:"status"`
+	FileSystem    string     `xml:"fileSystem,omitempty" json:"fileSystem,omitempty" yaml:"fileSystem,omitempty"`
+	CapacityBytes uint64     `xml:"capacityBytes" json:"capacityBytes" yaml:"capacityBytes"`
+	UsedBytes     uint64     `xml:"usedBytes" json:"usedBytes" yaml:"usedBytes"`
+	FreeBytes     uint64     `xml:"freeBytes" json:"freeBytes" yaml:"freeBytes"`
+	// ThresholdPercent is the usage at which QTS raises a capacity alert.
+	ThresholdPercent int `xml:"thresholdPercent" json:"thresholdPercent" yaml:"thresholdPercent"`
+}
+
+func newVolume(v qdocVolume) Volume {
+	return Volume{
+		ID:               v.VolumeID,
+		Label:            filterNullString(v.Label),
+		PoolID:           filterNullString(v.PoolID),
+		Type:             VolumeType(strings.ToLower(filterNullString(v.Type))),
+		Status:           filterNullString(v.Status),
+		FileSystem:       filterNullString(v.FileSystem),
+		CapacityBytes:    parseSize(v.CapacityBytes),
+		UsedBytes:        parseSize(v.UsedBytes),
+		FreeBytes:        parseSize(v.FreeBytes),
+		ThresholdPercent: parseInt(v.Threshold),
+	}
+}
+
+type PoolsResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Pools             []Pool
+}
+
+type PoolResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Pool              `yaml:",inline"`
+}
+
+type RAIDGroupsResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	RAIDGroups        []RAIDGroup
+}
+
+type RAIDGroupResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	RAIDGroup         `yaml:",inline"`
+}
+
+type VolumesResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Volumes           []Volume
+}
+
+type VolumeResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Volume            `yaml:",inline"`
+}
+
+// ErrNotConfirmed is returned by operations changing the volume layout if
+// they have not been explicitly confirmed.
+var ErrNotConfirmed = errors.New("operation not confirmed")
+
+// CreateVolumeOptions describes a new volume.
+type CreateVolumeOptions struct {
+	// PoolID is the storage pool of thick and thin volumes. It is ignored for
+	// static volumes.
+	PoolID string
+	// RAIDGroupID is the RAID group of static volumes.
+	RAIDGroupID      string
+	Label            string
+	Type             VolumeType
+	SizeBytes        uint64
+	ThresholdPercent int
+	// Confirm must be set to create the volume.
+	Confirm bool
+}
+
+// ExpandVolumeOptions describes the new size of a volume.
+type ExpandVolumeOptions struct {
+	SizeBytes uint64
+	// Confirm must be set to expand the volume.
+	Confirm bool
+}
+
+// DeleteVolumeOptions confirms the deletion of a volume and all its data.
+type DeleteVolumeOptions struct {
+	// ConfirmLabel must match the label of the volume to delete, as reported
+	// by the NAS. For a volume without a label it must match the volume ID.
+	ConfirmLabel string
+}
@@ -0,0 +1,1098 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// ListDisks returns all disks of the NAS and its expansion enclosures.
+func (client Client) ListDisks(ctx context.Context) (result DisksResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListDisks")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListDisksPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListDisks", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListDisksSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListDisks", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListDisksResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListDisks", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListDisksPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func": "all_hd_data",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/qsmart.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListDisksSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListDisksResponder(resp *http.Response) (result DisksResponse, err error) {
+	var doc qdocDiskList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Disks = make([]Disk, len(doc.DiskInfo.Entry))
+	for i, entry := range doc.DiskInfo.Entry {
+		result.Disks[i] = Disk{
+			ID:            entry.HDNo,
+			EnclosureID:   filterNullString(entry.EnclosureID),
+			Bay:           parseInt(entry.PortID),
+			Model:         filterNullString(entry.Model),
+			Serial:        filterNullString(entry.Serial),
+			CapacityBytes: parseSize(entry.Capacity),
+			TemperatureC:  parseInt(entry.Temperature.C),
+			Type:          DiskType(strings.ToLower(filterNullString(entry.Type))),
+			Health:        parseHealthStatus(entry.Health),
+			Status:        filterNullString(entry.Status),
+		}
+	}
+
+	return
+}
+
+// GetSMART returns the S.M.A.R.T. attributes and the health verdict of a disk.
+func (client Client) GetSMART(ctx context.Context, diskID string) (result SMARTResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSMART")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSMARTPreparer(ctx, diskID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetSMART", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetSMARTSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetSMART", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetSMARTResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetSMART", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSMARTPreparer(ctx context.Context, diskID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":  "get_hd_smartinfo",
+		"hd_no": autorest.Encode("query", diskID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/qsmart.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetSMARTSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetSMARTResponder(resp *http.Response) (result SMARTResponse, err error) {
+	var doc qdocSMART
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Health = parseHealthStatus(doc.Health)
+	result.Attributes = make([]SMARTAttribute, len(doc.SmartInfo.Entry))
+	for i, entry := range doc.SmartInfo.Entry {
+		result.Attributes[i] = SMARTAttribute{
+			ID:        parseInt(entry.ID),
+			Name:      filterNullString(entry.Name),
+			Value:     parseInt(entry.Value),
+			Worst:     parseInt(entry.Worst),
+			Threshold: parseInt(entry.Threshold),
+			Raw:       filterNullString(entry.RawData),
+			Status:    filterNullString(entry.Status),
+		}
+	}
+
+	return
+}
+
+// StartSelfTest starts a quick or complete S.M.A.R.T. self-test on a disk.
+func (client Client) StartSelfTest(ctx context.Context, diskID string, testType SelfTestType) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.StartSelfTest")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.StartSelfTestPreparer(ctx, diskID, testType)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "StartSelfTest", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.StartSelfTestSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "StartSelfTest", resp, "Failure sending request")
+		return
+	}
+
+	err = client.StartSelfTestResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "StartSelfTest", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) StartSelfTestPreparer(ctx context.Context, diskID string, testType SelfTestType) (*http.Request, error) {
+	test := "short"
+	if testType == SelfTestTypeComplete {
+		test = "long"
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/qsmart.cgi"),
+		autorest.WithFormData(url.Values{
+			"func":      []string{"start_test"},
+			"hd_no":     []string{diskID},
+			"test_type": []string{test},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// StartSelfTestSender sends the request without retries, so a lost response
+// does not abort and restart a test which is already running.
+func (client Client) StartSelfTestSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) StartSelfTestResponder(resp *http.Response) (err error) {
+	var doc qdocStorageOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("failed to start self-test: result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetSelfTestResult returns the state of the running or last S.M.A.R.T.
+// self-test of a disk.
+func (client Client) GetSelfTestResult(ctx context.Context, diskID string) (result SelfTestResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetSelfTestResult")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetSelfTestResultPreparer(ctx, diskID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetSelfTestResult", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetSelfTestResultSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetSelfTestResult", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetSelfTestResultResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetSelfTestResult", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetSelfTestResultPreparer(ctx context.Context, diskID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":  "get_test_result",
+		"hd_no": autorest.Encode("query", diskID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/qsmart.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetSelfTestResultSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetSelfTestResultResponder(resp *http.Response) (result SelfTestResponse, err error) {
+	var doc qdocSelfTest
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Type = SelfTestTypeQuick
+	if strings.EqualFold(doc.Test.Type, "long") || strings.EqualFold(doc.Test.Type, "complete") {
+		result.Type = SelfTestTypeComplete
+	}
+	result.Status = parseSelfTestStatus(doc.Test.Status)
+	result.Progress = parseInt(doc.Test.Progress)
+	result.Time = filterNullString(doc.Test.Time)
+
+	return
+}
+
+// ListPools returns all storage pools.
+func (client Client) ListPools(ctx context.Context) (result PoolsResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListPools")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListPoolsPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListPools", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListPoolsSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListPools", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListPoolsResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListPools", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListPoolsPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":      "extra_get",
+		"Pool_Info": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListPoolsSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListPoolsResponder(resp *http.Response) (result PoolsResponse, err error) {
+	var doc qdocPoolList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Pools = make([]Pool, len(doc.PoolIndex.Row))
+	for i, row := range doc.PoolIndex.Row {
+		result.Pools[i] = newPool(row)
+	}
+
+	return
+}
+
+// GetPool returns the storage pool poolID.
+func (client Client) GetPool(ctx context.Context, poolID string) (result PoolResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetPool")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetPoolPreparer(ctx, poolID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetPool", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetPoolSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetPool", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetPoolResponder(resp, poolID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetPool", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetPoolPreparer(ctx context.Context, poolID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":      "extra_get",
+		"Pool_Info": "1",
+		"poolID":    autorest.Encode("query", poolID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetPoolSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetPoolResponder(resp *http.Response, poolID string) (result PoolResponse, err error) {
+	var doc qdocPoolList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	// QTS ignores unknown filters and returns all rows, so the row is
+	// matched by ID.
+	for _, row := range doc.PoolIndex.Row {
+		if item := newPool(row); item.ID == poolID {
+			result.Pool = item
+			return
+		}
+	}
+	err = fmt.Errorf("pool %s not found", poolID)
+
+	return
+}
+
+// ListRAIDGroups returns all RAID groups including the progress of running
+// rebuild or resync operations.
+func (client Client) ListRAIDGroups(ctx context.Context) (result RAIDGroupsResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListRAIDGroups")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListRAIDGroupsPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListRAIDGroups", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListRAIDGroupsSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListRAIDGroups", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListRAIDGroupsResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListRAIDGroups", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListRAIDGroupsPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":      "extra_get",
+		"RAID_Info": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListRAIDGroupsSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListRAIDGroupsResponder(resp *http.Response) (result RAIDGroupsResponse, err error) {
+	var doc qdocRAIDList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.RAIDGroups = make([]RAIDGroup, len(doc.RAIDIndex.Row))
+	for i, row := range doc.RAIDIndex.Row {
+		result.RAIDGroups[i] = newRAIDGroup(row)
+	}
+
+	return
+}
+
+// GetRAIDGroup returns the RAID group raidID.
+func (client Client) GetRAIDGroup(ctx context.Context, raidID string) (result RAIDGroupResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetRAIDGroup")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetRAIDGroupPreparer(ctx, raidID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetRAIDGroup", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetRAIDGroupSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetRAIDGroup", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetRAIDGroupResponder(resp, raidID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetRAIDGroup", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetRAIDGroupPreparer(ctx context.Context, raidID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":      "extra_get",
+		"RAID_Info": "1",
+		"raidID":    autorest.Encode("query", raidID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetRAIDGroupSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetRAIDGroupResponder(resp *http.Response, raidID string) (result RAIDGroupResponse, err error) {
+	var doc qdocRAIDList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	// QTS ignores unknown filters and returns all rows, so the row is
+	// matched by ID.
+	for _, row := range doc.RAIDIndex.Row {
+		if item := newRAIDGroup(row); item.ID == raidID {
+			result.RAIDGroup = item
+			return
+		}
+	}
+	err = fmt.Errorf("RAID group %s not found", raidID)
+
+	return
+}
+
+// ListVolumes returns all volumes.
+func (client Client) ListVolumes(ctx context.Context) (result VolumesResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListVolumes")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListVolumesPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListVolumes", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListVolumesSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListVolumes", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListVolumesResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ListVolumes", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListVolumesPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":        "extra_get",
+		"Volume_Info": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListVolumesSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListVolumesResponder(resp *http.Response) (result VolumesResponse, err error) {
+	var doc qdocVolumeList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Volumes = make([]Volume, len(doc.VolumeIndex.Row))
+	for i, row := range doc.VolumeIndex.Row {
+		result.Volumes[i] = newVolume(row)
+	}
+
+	return
+}
+
+// GetVolume returns the volume volumeID.
+func (client Client) GetVolume(ctx context.Context, volumeID string) (result VolumeResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetVolume")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetVolumePreparer(ctx, volumeID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetVolume", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetVolumeSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetVolume", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetVolumeResponder(resp, volumeID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "GetVolume", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetVolumePreparer(ctx context.Context, volumeID string) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"func":        "extra_get",
+		"Volume_Info": "1",
+		"volumeID":    autorest.Encode("query", volumeID),
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetVolumeSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetVolumeResponder(resp *http.Response, volumeID string) (result VolumeResponse, err error) {
+	var doc qdocVolumeList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	// QTS ignores unknown filters and returns all rows, so the row is
+	// matched by ID.
+	for _, row := range doc.VolumeIndex.Row {
+		if item := newVolume(row); item.ID == volumeID {
+			result.Volume = item
+			return
+		}
+	}
+	err = fmt.Errorf("volume %s not found", volumeID)
+
+	return
+}
+
+// CreateVolume creates a new volume. opts.Confirm must be set.
+func (client Client) CreateVolume(ctx context.Context, opts CreateVolumeOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.CreateVolume")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	if !opts.Confirm {
+		err = ErrNotConfirmed
+		return
+	}
+
+	req, err := client.CreateVolumePreparer(ctx, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "CreateVolume", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateVolumeSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "CreateVolume", resp, "Failure sending request")
+		return
+	}
+
+	err = client.CreateVolumeResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "CreateVolume", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CreateVolumePreparer(ctx context.Context, opts CreateVolumeOptions) (*http.Request, error) {
+	data := url.Values{
+		"func":        []string{"create_volume"},
+		"volume_type": []string{string(opts.Type)},
+		"volumeLabel": []string{opts.Label},
+		"size_bytes":  []string{strconv.FormatUint(opts.SizeBytes, 10)},
+		"threshold":   []string{strconv.Itoa(opts.ThresholdPercent)},
+	}
+	if opts.Type == VolumeTypeStatic {
+		data.Set("raidID", opts.RAIDGroupID)
+	} else {
+		data.Set("poolID", opts.PoolID)
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CreateVolumeSender sends the request without retries, as a retry after a
+// lost response could create a second volume.
+func (client Client) CreateVolumeSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CreateVolumeResponder(resp *http.Response) (err error) {
+	var doc qdocStorageOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// ExpandVolume grows the volume volumeID to opts.SizeBytes. opts.Confirm
+// must be set.
+func (client Client) ExpandVolume(ctx context.Context, volumeID string, opts ExpandVolumeOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ExpandVolume")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	if !opts.Confirm {
+		err = ErrNotConfirmed
+		return
+	}
+
+	req, err := client.ExpandVolumePreparer(ctx, volumeID, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ExpandVolume", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ExpandVolumeSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ExpandVolume", resp, "Failure sending request")
+		return
+	}
+
+	err = client.ExpandVolumeResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "ExpandVolume", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ExpandVolumePreparer(ctx context.Context, volumeID string, opts ExpandVolumeOptions) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithFormData(url.Values{
+			"func":       []string{"expand_volume"},
+			"volumeID":   []string{volumeID},
+			"size_bytes": []string{strconv.FormatUint(opts.SizeBytes, 10)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// ExpandVolumeSender sends the request without retries, as the NAS may
+// already be expanding the volume when the response is lost.
+func (client Client) ExpandVolumeSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) ExpandVolumeResponder(resp *http.Response) (err error) {
+	var doc qdocStorageOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// DeleteVolume deletes the volume volumeID and all its data.
+// opts.ConfirmLabel must match the label of the volume, or its ID if the
+// volume has no label.
+func (client Client) DeleteVolume(ctx context.Context, volumeID string, opts DeleteVolumeOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.DeleteVolume")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	if opts.ConfirmLabel == "" {
+		err = ErrNotConfirmed
+		return
+	}
+
+	volume, err := client.GetVolume(ctx, volumeID)
+	if err != nil {
+		return
+	}
+	label := volume.Label
+	if label == "" {
+		label = volume.ID
+	}
+	if label != opts.ConfirmLabel {
+		err = fmt.Errorf("%w: volume %s is labeled %q", ErrNotConfirmed, volumeID, volume.Label)
+		return
+	}
+
+	req, err := client.DeleteVolumePreparer(ctx, volumeID)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "DeleteVolume", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteVolumeSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "DeleteVolume", resp, "Failure sending request")
+		return
+	}
+
+	err = client.DeleteVolumeResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "storage.Client", "DeleteVolume", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) DeleteVolumePreparer(ctx context.Context, volumeID string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/disk/disk_manage.cgi"),
+		autorest.WithFormData(url.Values{
+			"func":     []string{"remove_volume"},
+			"volumeID": []string{volumeID},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// DeleteVolumeSender sends the request without retries, as the volume ID
+// could be reused by then.
+func (client Client) DeleteVolumeSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) DeleteVolumeResponder(resp *http.Response) (err error) {
+	var doc qdocStorageOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
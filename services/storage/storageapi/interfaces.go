@@ -0,0 +1,26 @@
+package storageapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/storage"
+)
+
+// StorageClientAPI contains the set of methods on the storage.Client type.
+type StorageClientAPI interface {
+	ListDisks(ctx context.Context) (storage.DisksResponse, error)
+	GetSMART(ctx context.Context, diskID string) (storage.SMARTResponse, error)
+	StartSelfTest(ctx context.Context, diskID string, testType storage.SelfTestType) error
+	GetSelfTestResult(ctx context.Context, diskID string) (storage.SelfTestResponse, error)
+	ListPools(ctx context.Context) (storage.PoolsResponse, error)
+	GetPool(ctx context.Context, poolID string) (storage.PoolResponse, error)
+	ListRAIDGroups(ctx context.Context) (storage.RAIDGroupsResponse, error)
+	GetRAIDGroup(ctx context.Context, raidID string) (storage.RAIDGroupResponse, error)
+	ListVolumes(ctx context.Context) (storage.VolumesResponse, error)
+	GetVolume(ctx context.Context, volumeID string) (storage.VolumeResponse, error)
+	CreateVolume(ctx context.Context, opts storage.CreateVolumeOptions) error
+	ExpandVolume(ctx context.Context, volumeID string, opts storage.ExpandVolumeOptions) error
+	DeleteVolume(ctx context.Context, volumeID string, opts storage.DeleteVolumeOptions) error
+}
+
+var _ StorageClientAPI = (*storage.Client)(nil)
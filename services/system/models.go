@@ -0,0 +1,119 @@
+package system
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/system"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+// parseFloat parses numbers like "4.4 %" or "7859.9" and returns 0 for
+// values QTS does not report.
+func parseFloat(s string) float64 {
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(filterNullString(s)), "%"))
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+func parseInt(s string) int {
+	i, err := strconv.Atoi(strings.TrimSpace(filterNullString(s)))
+	if err != nil {
+		return 0
+	}
+	return i
+}
+
+type qdocSysInfo struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		Name       string `xml:"name"`
+		OwnContent struct {
+			Root struct {
+				Model struct {
+					ModelName         string `xml:"modelName"`
+					InternalModelName string `xml:"internalModelName"`
+					DisplayModelName  string `xml:"displayModelName"`
+					Platform          string `xml:"platform"`
+				} `xml:"model"`
+				Hostname     string `xml:"hostname"`
+				SerialNumber string `xml:"serial_number"`
+				Firmware     struct {
+					Version   string `xml:"version"`
+					Build     string `xml:"build"`
+					Patch     string `xml:"patch"`
+					BuildTime string `xml:"buildTime"`
+				} `xml:"firmware"`
+				UptimeDay   string `xml:"uptime_day"`
+				UptimeHour  string `xml:"uptime_hour"`
+				UptimeMin   string `xml:"uptime_min"`
+				UptimeSec   string `xml:"uptime_sec"`
+				CPUModel    string `xml:"cpu_model"`
+				CPUNum      string `xml:"cpu_num"`
+				CPUUsage    string `xml:"cpu_usage"`
+				CPUTempC    string `xml:"cpu_tempc"`
+				TotalMemory string `xml:"total_memory"`
+				FreeMemory  string `xml:"free_memory"`
+				SysTempC    string `xml:"sys_tempc"`
+			} `xml:"root"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+// FirmwareInfo describes the installed QTS firmware.
+type FirmwareInfo struct {
+	Version   string `xml:"version" json:"version" yaml:"version"`
+	Build     string `xml:"build" json:"build" yaml:"build"`
+	Patch     string `xml:"patch,omitempty" json:"patch,omitempty" yaml:"patch,omitempty"`
+	BuildTime string `xml:"buildTime,omitempty" json:"buildTime,omitempty" yaml:"buildTime,omitempty"`
+}
+
+// CPUInfo describes the processor of the NAS.
+type CPUInfo struct {
+	Model string `xml:"model" json:"model" yaml:"model"`
+	Cores int    `xml:"cores" json:"cores" yaml:"cores"`
+	// Usage is the CPU load in percent.
+	Usage float64 `xml:"usage" json:"usage" yaml:"usage"`
+	// TemperatureC is the CPU temperature in degree Celsius.
+	TemperatureC float64 `xml:"temperatureC" json:"temperatureC" yaml:"temperatureC"`
+}
+
+// MemoryInfo describes the physical memory of the NAS in MiB.
+type MemoryInfo struct {
+	TotalMB float64 `xml:"totalMB" json:"totalMB" yaml:"totalMB"`
+	FreeMB  float64 `xml:"freeMB" json:"freeMB" yaml:"freeMB"`
+}
+
+// SystemInfo contains hardware and firmware information of the NAS.
+type SystemInfo struct {
+	Hostname      string        `xml:"hostname" json:"hostname" yaml:"hostname"`
+	Model         string        `xml:"model" json:"model" yaml:"model"`
+	InternalModel string        `xml:"internalModel,omitempty" json:"internalModel,omitempty" yaml:"internalModel,omitempty"`
+	Platform      string        `xml:"platform,omitempty" json:"platform,omitempty" yaml:"platform,omitempty"`
+	SerialNumber  string        `xml:"serialNumber" json:"serialNumber" yaml:"serialNumber"`
+	Firmware      FirmwareInfo  `xml:"firmware" json:"firmware" yaml:"firmware"`
+	Uptime        time.Duration `xml:"uptime" json:"uptime" yaml:"uptime"`
+	CPU           CPUInfo       `xml:"cpu" json:"cpu" yaml:"cpu"`
+	Memory        MemoryInfo    `xml:"memory" json:"memory" yaml:"memory"`
+	// TemperatureC is the system temperature in degree Celsius.
+	TemperatureC float64 `xml:"temperatureC" json:"temperatureC" yaml:"temperatureC"`
+}
+
+type InfoResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	SystemInfo        `yaml:",inline"`
+}
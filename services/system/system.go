@@ -0,0 +1,135 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// GetInfo returns model, serial number, firmware, uptime, CPU, memory and
+// temperature information of the NAS.
+func (client Client) GetInfo(ctx context.Context) (result InfoResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetInfo")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetInfoPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "system.Client", "GetInfo", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetInfoSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "system.Client", "GetInfo", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetInfoResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "system.Client", "GetInfo", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetInfoPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc":  "sysinfo",
+		"hd":       "no",
+		"multicpu": "1",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/management/manaRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetInfoSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetInfoResponder(resp *http.Response) (result InfoResponse, err error) {
+	var doc qdocSysInfo
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	root := doc.Func.OwnContent.Root
+	model := filterNullString(root.Model.DisplayModelName)
+	if model == "" {
+		model = filterNullString(root.Model.ModelName)
+	}
+
+	result.SystemInfo = SystemInfo{
+		Hostname:      filterNullString(root.Hostname),
+		Model:         model,
+		InternalModel: filterNullString(root.Model.InternalModelName),
+		Platform:      filterNullString(root.Model.Platform),
+		SerialNumber:  filterNullString(root.SerialNumber),
+		Firmware: FirmwareInfo{
+			Version:   filterNullString(root.Firmware.Version),
+			Build:     filterNullString(root.Firmware.Build),
+			Patch:     filterNullString(root.Firmware.Patch),
+			BuildTime: filterNullString(root.Firmware.BuildTime),
+		},
+		Uptime: time.Duration(parseInt(root.UptimeDay))*24*time.Hour +
+			time.Duration(parseInt(root.UptimeHour))*time.Hour +
+			time.Duration(parseInt(root.UptimeMin))*time.Minute +
+			time.Duration(parseInt(root.UptimeSec))*time.Second,
+		CPU: CPUInfo{
+			Model:        filterNullString(root.CPUModel),
+			Cores:        parseInt(root.CPUNum),
+			Usage:        parseFloat(root.CPUUsage),
+			TemperatureC: parseFloat(root.CPUTempC),
+		},
+		Memory: MemoryInfo{
+			TotalMB: parseFloat(root.TotalMemory),
+			FreeMB:  parseFloat(root.FreeMemory),
+		},
+		TemperatureC: parseFloat(root.SysTempC),
+	}
+
+	return
+}
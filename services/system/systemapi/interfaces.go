@@ -0,0 +1,14 @@
+package systemapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/system"
+)
+
+// SystemClientAPI contains the set of methods on the system.Client type.
+type SystemClientAPI interface {
+	GetInfo(ctx context.Context) (system.InfoResponse, error)
+}
+
+var _ SystemClientAPI = (*system.Client)(nil)
@@ -0,0 +1,160 @@
+package users
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+)
+
+const fqdn = "github.com/qnap/core-sdk-for-go/services/users"
+
+func filterNullString(s string) string {
+	if strings.EqualFold(s, "null") {
+		return ""
+	}
+	return s
+}
+
+func parseUint(s string) uint64 {
+	u, err := strconv.ParseUint(strings.TrimSpace(filterNullString(s)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return u
+}
+
+func boolString(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+type qdocUserList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			User []struct {
+				Username    string   `xml:"username"`
+				Description string   `xml:"description"`
+				Email       string   `xml:"email"`
+				Groups      []string `xml:"groups>group"`
+				Quota       string   `xml:"quota_bytes"`
+				Disabled    string   `xml:"disabled"`
+				IsAdmin     string   `xml:"is_admin"`
+			} `xml:"user"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocGroupList struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Group []struct {
+				Groupname   string   `xml:"groupname"`
+				Description string   `xml:"description"`
+				Members     []string `xml:"members>member"`
+			} `xml:"group"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocHomeFolder struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Func       struct {
+		OwnContent struct {
+			Enable string `xml:"enable"`
+			Volume string `xml:"volume"`
+		} `xml:"ownContent"`
+	} `xml:"func"`
+}
+
+type qdocUserOp struct {
+	XMLName    xml.Name `xml:"QDocRoot"`
+	AuthPassed int      `xml:"authPassed"`
+	Result     string   `xml:"result"`
+}
+
+// User is a local user of the NAS.
+type User struct {
+	Name        string   `xml:"name" json:"name" yaml:"name"`
+	Description string   `xml:"description,omitempty" json:"description,omitempty" yaml:"description,omitempty"`
+	Email       string   `xml:"email,omitempty" json:"email,omitempty" yaml:"email,omitempty"`
+	Groups      []string `xml:"groups" json:"groups" yaml:"groups"`
+	// QuotaBytes limits the space used by the user. Zero means no limit.
+	QuotaBytes uint64 `xml:"quotaBytes" json:"quotaBytes" yaml:"quotaBytes"`
+	Disabled   bool   `xml:"disabled" json:"disabled" yaml:"disabled"`
+	IsAdmin    bool   `xml:"isAdmin" json:"isAdmin" yaml:"isAdmin"`
+}
+
+type UsersResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Users             []User
+}
+
+// Group is a local group of the NAS.
+type Group struct {
+	Name        string   `xml:"name" json:"name" yaml:"name"`
+	Description string   `xml:"description,omitempty" json:"description,omitempty" yaml:"description,omitempty"`
+	Members     []string `xml:"members" json:"members" yaml:"members"`
+}
+
+type GroupsResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	Groups            []Group
+}
+
+// CreateUserOptions describes a new local user.
+type CreateUserOptions struct {
+	Name        string
+	Password    string
+	Description string
+	Email       string
+	Groups      []string
+	// QuotaBytes limits the space used by the user. Zero means no limit.
+	QuotaBytes uint64
+}
+
+// UpdateUserOptions replaces the settings of a local user.
+type UpdateUserOptions struct {
+	// Password is only changed if not empty.
+	Password    string
+	Description string
+	Email       string
+	// QuotaBytes limits the space used by the user. Zero means no limit.
+	QuotaBytes uint64
+	Disabled   bool
+	// Groups replaces the groups of the user unless it is nil. Use an empty
+	// slice to remove the user from all groups.
+	Groups []string
+}
+
+// CreateGroupOptions describes a new local group.
+type CreateGroupOptions struct {
+	Name        string
+	Description string
+	Members     []string
+}
+
+// UpdateGroupOptions replaces the settings of a local group.
+type UpdateGroupOptions struct {
+	Description string
+}
+
+// HomeFolderSetting controls whether every user gets a home folder.
+type HomeFolderSetting struct {
+	Enabled bool `xml:"enabled" json:"enabled" yaml:"enabled"`
+	// Volume holding the home folders.
+	Volume string `xml:"volume,omitempty" json:"volume,omitempty" yaml:"volume,omitempty"`
+}
+
+type HomeFolderResponse struct {
+	autorest.Response `xml:"-" json:"-" yaml:"-"`
+	HomeFolderSetting `yaml:",inline"`
+}
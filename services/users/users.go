@@ -0,0 +1,877 @@
+package users
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/tracing"
+)
+
+type Client struct {
+	BaseClient
+}
+
+// NewClient creates an instance of the Client client.
+func NewClient() Client {
+	return NewClientWithBaseURI(DefaultBaseURI)
+}
+
+// NewClientWithBaseURI creates an instance of the Client client using a custom endpoint.
+func NewClientWithBaseURI(baseURI string) Client {
+	return Client{NewWithBaseURI(baseURI)}
+}
+
+// ListUsers returns all local users.
+func (client Client) ListUsers(ctx context.Context) (result UsersResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListUsers")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListUsersPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "ListUsers", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListUsersSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "users.Client", "ListUsers", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListUsersResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "ListUsers", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListUsersPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "user_mgmt",
+		"func":    "get_user_list",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListUsersSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListUsersResponder(resp *http.Response) (result UsersResponse, err error) {
+	var doc qdocUserList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Users = make([]User, len(doc.Func.OwnContent.User))
+	for i, user := range doc.Func.OwnContent.User {
+		result.Users[i] = User{
+			Name:        user.Username,
+			Description: filterNullString(user.Description),
+			Email:       filterNullString(user.Email),
+			Groups:      append([]string(nil), user.Groups...),
+			QuotaBytes:  parseUint(user.Quota),
+			Disabled:    user.Disabled == "1",
+			IsAdmin:     user.IsAdmin == "1",
+		}
+	}
+
+	return
+}
+
+// CreateUser creates a new local user.
+func (client Client) CreateUser(ctx context.Context, opts CreateUserOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.CreateUser")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CreateUserPreparer(ctx, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "CreateUser", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateUserSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "CreateUser", resp, "Failure sending request")
+		return
+	}
+
+	err = client.CreateUserResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "CreateUser", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CreateUserPreparer(ctx context.Context, opts CreateUserOptions) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":     []string{"user_mgmt"},
+			"func":        []string{"add_user"},
+			"username":    []string{opts.Name},
+			"pwd":         []string{base64.StdEncoding.EncodeToString([]byte(opts.Password))},
+			"description": []string{opts.Description},
+			"email":       []string{opts.Email},
+			"groups":      []string{strings.Join(opts.Groups, ",")},
+			"quota_bytes": []string{strconv.FormatUint(opts.QuotaBytes, 10)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CreateUserSender sends the request without retries, as a retry after a
+// lost response would fail because the user already exists.
+func (client Client) CreateUserSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CreateUserResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// UpdateUser replaces the settings of the local user name.
+func (client Client) UpdateUser(ctx context.Context, name string, opts UpdateUserOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.UpdateUser")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.UpdateUserPreparer(ctx, name, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "UpdateUser", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.UpdateUserSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "UpdateUser", resp, "Failure sending request")
+		return
+	}
+
+	err = client.UpdateUserResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "UpdateUser", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) UpdateUserPreparer(ctx context.Context, name string, opts UpdateUserOptions) (*http.Request, error) {
+	data := url.Values{
+		"subfunc":     []string{"user_mgmt"},
+		"func":        []string{"modify_user"},
+		"username":    []string{name},
+		"description": []string{opts.Description},
+		"email":       []string{opts.Email},
+		"quota_bytes": []string{strconv.FormatUint(opts.QuotaBytes, 10)},
+		"disabled":    []string{boolString(opts.Disabled)},
+	}
+	if opts.Password != "" {
+		data.Set("pwd", base64.StdEncoding.EncodeToString([]byte(opts.Password)))
+	}
+	if opts.Groups != nil {
+		data.Set("groups", strings.Join(opts.Groups, ","))
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(data))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// UpdateUserSender sends the request without retries, like the other
+// requests changing users and groups.
+func (client Client) UpdateUserSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) UpdateUserResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// DeleteUser removes the local user name. The home folder of the user is only
+// removed if deleteHome is set.
+func (client Client) DeleteUser(ctx context.Context, name string, deleteHome bool) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.DeleteUser")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DeleteUserPreparer(ctx, name, deleteHome)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "DeleteUser", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteUserSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "DeleteUser", resp, "Failure sending request")
+		return
+	}
+
+	err = client.DeleteUserResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "DeleteUser", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) DeleteUserPreparer(ctx context.Context, name string, deleteHome bool) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":  []string{"user_mgmt"},
+			"func":     []string{"del_user"},
+			"username": []string{name},
+			"del_home": []string{boolString(deleteHome)},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// DeleteUserSender sends the request without retries, as the user and the
+// home folder may already be deleted when the response is lost.
+func (client Client) DeleteUserSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) DeleteUserResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// ListGroups returns all local groups and their members.
+func (client Client) ListGroups(ctx context.Context) (result GroupsResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.ListGroups")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.ListGroupsPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "ListGroups", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.ListGroupsSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "users.Client", "ListGroups", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.ListGroupsResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "ListGroups", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) ListGroupsPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "group_mgmt",
+		"func":    "get_group_list",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) ListGroupsSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) ListGroupsResponder(resp *http.Response) (result GroupsResponse, err error) {
+	var doc qdocGroupList
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Groups = make([]Group, len(doc.Func.OwnContent.Group))
+	for i, group := range doc.Func.OwnContent.Group {
+		result.Groups[i] = Group{
+			Name:        group.Groupname,
+			Description: filterNullString(group.Description),
+			Members:     append([]string(nil), group.Members...),
+		}
+	}
+
+	return
+}
+
+// CreateGroup creates a new local group.
+func (client Client) CreateGroup(ctx context.Context, opts CreateGroupOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.CreateGroup")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.CreateGroupPreparer(ctx, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "CreateGroup", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.CreateGroupSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "CreateGroup", resp, "Failure sending request")
+		return
+	}
+
+	err = client.CreateGroupResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "CreateGroup", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) CreateGroupPreparer(ctx context.Context, opts CreateGroupOptions) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":     []string{"group_mgmt"},
+			"func":        []string{"add_group"},
+			"groupname":   []string{opts.Name},
+			"description": []string{opts.Description},
+			"members":     []string{strings.Join(opts.Members, ",")},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// CreateGroupSender sends the request without retries, as a retry after a
+// lost response would fail because the group already exists.
+func (client Client) CreateGroupSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) CreateGroupResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// UpdateGroup replaces the settings of the local group name.
+func (client Client) UpdateGroup(ctx context.Context, name string, opts UpdateGroupOptions) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.UpdateGroup")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.UpdateGroupPreparer(ctx, name, opts)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "UpdateGroup", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.UpdateGroupSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "UpdateGroup", resp, "Failure sending request")
+		return
+	}
+
+	err = client.UpdateGroupResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "UpdateGroup", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) UpdateGroupPreparer(ctx context.Context, name string, opts UpdateGroupOptions) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":     []string{"group_mgmt"},
+			"func":        []string{"modify_group"},
+			"groupname":   []string{name},
+			"description": []string{opts.Description},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// UpdateGroupSender sends the request without retries, like the other
+// requests changing users and groups.
+func (client Client) UpdateGroupSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) UpdateGroupResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// DeleteGroup removes the local group name.
+func (client Client) DeleteGroup(ctx context.Context, name string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.DeleteGroup")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.DeleteGroupPreparer(ctx, name)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "DeleteGroup", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.DeleteGroupSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "DeleteGroup", resp, "Failure sending request")
+		return
+	}
+
+	err = client.DeleteGroupResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "DeleteGroup", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) DeleteGroupPreparer(ctx context.Context, name string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":   []string{"group_mgmt"},
+			"func":      []string{"del_group"},
+			"groupname": []string{name},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// DeleteGroupSender sends the request without retries, like the other
+// requests changing users and groups.
+func (client Client) DeleteGroupSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) DeleteGroupResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// SetGroupMembers replaces the members of the local group name.
+func (client Client) SetGroupMembers(ctx context.Context, name string, members []string) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetGroupMembers")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetGroupMembersPreparer(ctx, name, members)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "SetGroupMembers", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetGroupMembersSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "SetGroupMembers", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetGroupMembersResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "SetGroupMembers", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetGroupMembersPreparer(ctx context.Context, name string, members []string) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc":   []string{"group_mgmt"},
+			"func":      []string{"set_group_members"},
+			"groupname": []string{name},
+			"members":   []string{strings.Join(members, ",")},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// SetGroupMembersSender sends the request without retries, so a retry cannot
+// overwrite a change made after the lost response.
+func (client Client) SetGroupMembersSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) SetGroupMembersResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
+
+// GetHomeFolder returns whether home folders are enabled for all users.
+func (client Client) GetHomeFolder(ctx context.Context) (result HomeFolderResponse, err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.GetHomeFolder")
+		defer func() {
+			sc := -1
+			if result.Response.Response != nil {
+				sc = result.Response.Response.StatusCode
+			}
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.GetHomeFolderPreparer(ctx)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "GetHomeFolder", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.GetHomeFolderSender(req)
+	if err != nil {
+		result.Response = autorest.Response{Response: resp}
+		err = autorest.NewErrorWithError(err, "users.Client", "GetHomeFolder", resp, "Failure sending request")
+		return
+	}
+
+	result, err = client.GetHomeFolderResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "GetHomeFolder", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) GetHomeFolderPreparer(ctx context.Context) (*http.Request, error) {
+	queryParameters := map[string]interface{}{
+		"subfunc": "user_mgmt",
+		"func":    "get_home_folder",
+	}
+
+	preparer := autorest.CreatePreparer(
+		autorest.AsGet(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithQueryParameters(queryParameters))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+func (client Client) GetHomeFolderSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req, autorest.DoRetryForStatusCodes(client.RetryAttempts, client.RetryDuration, autorest.StatusCodesForRetry...))
+}
+
+func (client Client) GetHomeFolderResponder(resp *http.Response) (result HomeFolderResponse, err error) {
+	var doc qdocHomeFolder
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	result.Response = autorest.Response{Response: resp}
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	result.Enabled = doc.Func.OwnContent.Enable == "1"
+	result.Volume = filterNullString(doc.Func.OwnContent.Volume)
+
+	return
+}
+
+// SetHomeFolder enables or disables home folders for all users.
+func (client Client) SetHomeFolder(ctx context.Context, setting HomeFolderSetting) (err error) {
+	if tracing.IsEnabled() {
+		ctx = tracing.StartSpan(ctx, fqdn+"/Client.SetHomeFolder")
+		defer func() {
+			sc := -1
+			tracing.EndSpan(ctx, sc, err)
+		}()
+	}
+	req, err := client.SetHomeFolderPreparer(ctx, setting)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "SetHomeFolder", nil, "Failure preparing request")
+		return
+	}
+
+	resp, err := client.SetHomeFolderSender(req)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "SetHomeFolder", resp, "Failure sending request")
+		return
+	}
+
+	err = client.SetHomeFolderResponder(resp)
+	if err != nil {
+		err = autorest.NewErrorWithError(err, "users.Client", "SetHomeFolder", resp, "Failure responding to request")
+		return
+	}
+
+	return
+}
+
+func (client Client) SetHomeFolderPreparer(ctx context.Context, setting HomeFolderSetting) (*http.Request, error) {
+	preparer := autorest.CreatePreparer(
+		autorest.AsPost(),
+		autorest.WithBaseURL(client.BaseURI+"/priv/privRequest.cgi"),
+		autorest.WithFormData(url.Values{
+			"subfunc": []string{"user_mgmt"},
+			"func":    []string{"set_home_folder"},
+			"enable":  []string{boolString(setting.Enabled)},
+			"volume":  []string{setting.Volume},
+		}))
+	return preparer.Prepare((&http.Request{}).WithContext(ctx))
+}
+
+// SetHomeFolderSender sends the request without retries, as the NAS may
+// already be creating the home folders when the response is lost.
+func (client Client) SetHomeFolderSender(req *http.Request) (*http.Response, error) {
+	return client.Send(req)
+}
+
+func (client Client) SetHomeFolderResponder(resp *http.Response) (err error) {
+	var doc qdocUserOp
+	err = autorest.Respond(
+		resp,
+		autorest.WithErrorUnlessStatusCode(http.StatusOK),
+		autorest.ByUnmarshallingXML(&doc),
+		autorest.ByClosing())
+
+	if err != nil {
+		return
+	}
+
+	if doc.AuthPassed != 1 {
+		err = fmt.Errorf("unauthorized")
+		return
+	}
+
+	if doc.Result != "" && doc.Result != "0" {
+		err = fmt.Errorf("operation failed with result %s", doc.Result)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,24 @@
+package usersapi
+
+import (
+	"context"
+
+	"github.com/qnap/core-sdk-for-go/services/users"
+)
+
+// UsersClientAPI contains the set of methods on the users.Client type.
+type UsersClientAPI interface {
+	ListUsers(ctx context.Context) (users.UsersResponse, error)
+	CreateUser(ctx context.Context, opts users.CreateUserOptions) error
+	UpdateUser(ctx context.Context, name string, opts users.UpdateUserOptions) error
+	DeleteUser(ctx context.Context, name string, deleteHome bool) error
+	ListGroups(ctx context.Context) (users.GroupsResponse, error)
+	CreateGroup(ctx context.Context, opts users.CreateGroupOptions) error
+	UpdateGroup(ctx context.Context, name string, opts users.UpdateGroupOptions) error
+	DeleteGroup(ctx context.Context, name string) error
+	SetGroupMembers(ctx context.Context, name string, members []string) error
+	GetHomeFolder(ctx context.Context) (users.HomeFolderResponse, error)
+	SetHomeFolder(ctx context.Context, setting users.HomeFolderSetting) error
+}
+
+var _ UsersClientAPI = (*users.Client)(nil)